@@ -0,0 +1,59 @@
+package mathext
+
+import "math"
+
+// agmMaxIter is the iteration cap used by AGM. The iteration converges
+// quadratically, so even extreme ratios of a to b finish well before it.
+const agmMaxIter = 64
+
+// agmTol is the relative spread of a and b at which the arithmetic and
+// geometric means are considered equal.
+const agmTol = 0x1p-52
+
+// AGM computes the arithmetic-geometric mean of a and b. a and b must be
+// non-negative.
+func AGM(a, b float64) float64 {
+	mean, _ := AGMIter(a, b, agmMaxIter)
+	return mean
+}
+
+// AGMIter computes the arithmetic-geometric mean of a and b taking at most
+// maxIter steps. It reports whether the means agreed to within a relative
+// 2⁻⁵² before the cap was reached. a and b must be non-negative.
+func AGMIter(a, b float64, maxIter int) (mean float64, converged bool) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(a) || math.IsNaN(b) || a < 0 || b < 0 {
+		return math.NaN(), false
+	}
+
+	// The mean collapses to zero or grows without bound with either of
+	// its arguments.
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	if math.IsInf(a, 1) || math.IsInf(b, 1) {
+		return math.Inf(1), true
+	}
+
+	mean, _, converged = agmSteps(a, b, maxIter)
+	return mean, converged
+}
+
+// agmSteps runs the iteration of AGMIter on positive, finite a and b and
+// also reports the number of steps taken. It holds the only convergence
+// test of the real means.
+func agmSteps(a, b float64, maxIter int) (mean float64, iters int, converged bool) {
+
+	// Replace the pair by their arithmetic and geometric means until they
+	// agree. The square roots are taken separately and the arithmetic mean
+	// is formed from the difference to avoid overflow.
+	for ; iters < maxIter; iters++ {
+		if math.Abs(a-b) <= agmTol*a {
+			return a + (b-a)/2, iters, true
+		}
+		a, b = a+(b-a)/2, math.Sqrt(a)*math.Sqrt(b)
+	}
+
+	return a + (b-a)/2, iters, math.Abs(a-b) <= agmTol*a
+}
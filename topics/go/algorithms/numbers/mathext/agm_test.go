@@ -0,0 +1,83 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestAGM(t *testing.T) {
+	tt := []struct {
+		name string
+		a, b float64
+		want float64
+	}{
+		{"gauss", 1, math.Sqrt2, 1.1981402347355922},
+		{"integer", 24, 6, 13.458171481725615},
+		{"equal", 3, 3, 3},
+		{"zero", 5, 0, 0},
+	}
+
+	t.Log("Given the need to compute the arithmetic-geometric mean.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking AGM(%v, %v).", testID, test.a, test.b)
+				{
+					got := mathext.AGM(test.a, test.b)
+					if e := relErr(got, test.want); e > 4e-16 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v.", failed, testID, test.want, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestAGMIter(t *testing.T) {
+	t.Log("Given the need to bound the arithmetic-geometric mean iteration.")
+	{
+		t.Logf("\tTest 0:\tWhen the arguments are well conditioned.")
+		{
+			for _, b := range []float64{0.9, 0.5, 0.25, 0.1, math.Sqrt2} {
+				mean, ok := mathext.AGMIter(1, b, 6)
+				if !ok || mean != mathext.AGM(1, b) {
+					t.Fatalf("\t%s\tTest 0:\tShould converge in 6 steps for b=%v, got %v %v.", failed, b, mean, ok)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould converge in at most 6 steps.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen the cap is reached.")
+		{
+			a, b := 1.0, 1e-300
+			for i := 0; i < 2; i++ {
+				a, b = a+(b-a)/2, math.Sqrt(a)*math.Sqrt(b)
+			}
+			mean, ok := mathext.AGMIter(1, 1e-300, 2)
+			if ok || mean != a+(b-a)/2 {
+				t.Fatalf("\t%s\tTest 1:\tShould stop after 2 steps at %v, got %v %v.", failed, a+(b-a)/2, mean, ok)
+			}
+			t.Logf("\t%s\tTest 1:\tShould stop after 2 steps and report no convergence.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen the arguments are near the largest float64.")
+		{
+			const max = math.MaxFloat64
+			if mean, ok := mathext.AGMIter(max, max, 64); !ok || mean != max {
+				t.Fatalf("\t%s\tTest 2:\tShould get %v for equal arguments, got %v %v.", failed, max, mean, ok)
+			}
+
+			// Scaling by an even power of two is exact for both means.
+			got := mathext.AGM(max, max/4)
+			want := 0x1p1000 * mathext.AGM(max*0x1p-1000, max*0x1p-1002)
+			if got != want {
+				t.Fatalf("\t%s\tTest 2:\tShould get %v for AGM(max, max/4), got %v.", failed, want, got)
+			}
+			t.Logf("\t%s\tTest 2:\tShould not overflow.", succeed)
+		}
+	}
+}
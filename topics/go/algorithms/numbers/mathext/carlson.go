@@ -0,0 +1,492 @@
+package mathext
+
+import "math"
+
+// carlsonTol is the relative error target for the duplication iterations.
+const carlsonTol = 1.0 / (1 << 53)
+
+// Stopping thresholds from Carlson (1995). The iteration stops once the
+// scaled spread of the arguments drops below the running mean.
+var (
+	rfScale = math.Pow(3*carlsonTol, -1.0/6)
+	rdScale = math.Pow(carlsonTol/4, -1.0/6)
+	rcScale = math.Pow(3*carlsonTol, -1.0/8)
+)
+
+// rf computes the Carlson symmetric integral of the first kind.
+//
+//	RF(x, y, z) = ½ ∫₀^∞ dt / √((t+x)(t+y)(t+z))
+//
+// x, y and z must be non-negative and at most one of them can be zero.
+func rf(x, y, z float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsNaN(z) || x < 0 || y < 0 || z < 0 {
+		return math.NaN()
+	}
+
+	// The integral diverges when two of the arguments are zero and
+	// vanishes when any of them is infinite.
+	if x+y == 0 || y+z == 0 || x+z == 0 {
+		return math.Inf(1)
+	}
+	if math.IsInf(x+y+z, 1) {
+		return 0
+	}
+
+	a0 := (x + y + z) / 3
+	q := rfScale * math.Max(math.Abs(a0-x), math.Max(math.Abs(a0-y), math.Abs(a0-z)))
+
+	// Apply the duplication theorem until the arguments are close enough
+	// for the Taylor expansion around their mean to be exact.
+	a, fac := a0, 1.0
+	xn, yn, zn := x, y, z
+	for q >= math.Abs(a) {
+		sx, sy, sz := math.Sqrt(xn), math.Sqrt(yn), math.Sqrt(zn)
+		lambda := sx*sy + sx*sz + sy*sz
+
+		xn = (xn + lambda) / 4
+		yn = (yn + lambda) / 4
+		zn = (zn + lambda) / 4
+		a = (a + lambda) / 4
+		fac /= 4
+		q /= 4
+	}
+
+	// Evaluate the fifth order expansion in the normalized deviations.
+	dx := (a0 - x) * fac / a
+	dy := (a0 - y) * fac / a
+	dz := -(dx + dy)
+	e2 := dx*dy - dz*dz
+	e3 := dx * dy * dz
+
+	return (1 - e2/10 + e3/14 + e2*e2/24 - 3*e2*e3/44) / math.Sqrt(a)
+}
+
+// rd computes the Carlson symmetric integral of the second kind.
+//
+//	RD(x, y, z) = 3/2 ∫₀^∞ dt / ((t+z)√((t+x)(t+y)(t+z)))
+//
+// x and y must be non-negative with at most one of them zero and z must
+// be positive.
+func rd(x, y, z float64) float64 {
+	return rdScaled(x, y, z, rdScale)
+}
+
+// rdScaled evaluates RD with the stopping threshold scale, which is
+// (r/4)^(-1/6) for a relative error target r.
+func rdScaled(x, y, z, scale float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsNaN(z) || x < 0 || y < 0 || z < 0 {
+		return math.NaN()
+	}
+
+	// The integral diverges when z is zero or both x and y are zero.
+	if z == 0 || x+y == 0 {
+		return math.Inf(1)
+	}
+	if math.IsInf(x+y+z, 1) {
+		return 0
+	}
+
+	a0 := (x + y + 3*z) / 5
+	q := scale * math.Max(math.Abs(a0-x), math.Max(math.Abs(a0-y), math.Abs(a0-z)))
+
+	// Apply the duplication theorem, collecting the contribution of the
+	// z argument at every step.
+	a, fac, sum := a0, 1.0, 0.0
+	xn, yn, zn := x, y, z
+	for q >= math.Abs(a) {
+		sx, sy, sz := math.Sqrt(xn), math.Sqrt(yn), math.Sqrt(zn)
+		lambda := sx*sy + sx*sz + sy*sz
+		sum += fac / (sz * (zn + lambda))
+
+		xn = (xn + lambda) / 4
+		yn = (yn + lambda) / 4
+		zn = (zn + lambda) / 4
+		a = (a + lambda) / 4
+		fac /= 4
+		q /= 4
+	}
+
+	// Evaluate the fifth order expansion in the normalized deviations.
+	dx := (a0 - x) * fac / a
+	dy := (a0 - y) * fac / a
+	return fac*rdSeries(dx, dy)/(a*math.Sqrt(a)) + 3*sum
+}
+
+// rdSeries evaluates the fifth order expansion that finishes RD from the
+// normalized deviations of x and y from the mean.
+func rdSeries(dx, dy float64) float64 {
+	dz := -(dx + dy) / 3
+	xy, z2 := dx*dy, dz*dz
+	e2 := xy - 6*z2
+	e3 := (3*xy - 8*z2) * dz
+	e4 := 3 * (xy - z2) * z2
+	e5 := xy * z2 * dz
+
+	return 1 - 3*e2/14 + e3/6 + 9*e2*e2/88 - 3*e4/22 - 9*e2*e3/52 + 3*e5/26
+}
+
+// rdComplete computes RD(0, 1, mc) and RD(0, mc, 1), the Carlson forms of
+// dK/dm and dE/dm, in one pass. λ is symmetric in the arguments, so both
+// integrals duplicate the same three values and differ only in which of
+// them is z. The loop runs until both have converged. mc must be in
+// [0, 1].
+func rdComplete(mc float64) (r1, r2 float64) {
+	if mc == 0 {
+		return math.Inf(1), math.Inf(1)
+	}
+
+	// The duplicated arguments start at 0, 1 and mc, and the means weigh
+	// mc and 1 three times in turn.
+	a10, a20 := (1+3*mc)/5, (mc+3)/5
+	q1 := rdScale * math.Max(a10, math.Max(math.Abs(a10-1), math.Abs(a10-mc)))
+	q2 := rdScale * math.Max(a20, math.Max(math.Abs(a20-1), math.Abs(a20-mc)))
+
+	a1, a2, fac, sum1, sum2 := a10, a20, 1.0, 0.0, 0.0
+	xn, un, vn := 0.0, 1.0, mc
+	for q1 >= a1 || q2 >= a2 {
+		sx, su, sv := math.Sqrt(xn), math.Sqrt(un), math.Sqrt(vn)
+		lambda := sx*su + sx*sv + su*sv
+		sum1 += fac / (sv * (vn + lambda))
+		sum2 += fac / (su * (un + lambda))
+
+		xn = (xn + lambda) / 4
+		un = (un + lambda) / 4
+		vn = (vn + lambda) / 4
+		a1 = (a1 + lambda) / 4
+		a2 = (a2 + lambda) / 4
+		fac /= 4
+		q1 /= 4
+		q2 /= 4
+	}
+
+	r1 = fac*rdSeries(a10*fac/a1, (a10-1)*fac/a1)/(a1*math.Sqrt(a1)) + 3*sum1
+	r2 = fac*rdSeries(a20*fac/a2, (a20-mc)*fac/a2)/(a2*math.Sqrt(a2)) + 3*sum2
+	return r1, r2
+}
+
+// rc computes the degenerate Carlson integral.
+//
+//	RC(x, y) = ½ ∫₀^∞ dt / ((t+y)√(t+x))
+//
+// x must be non-negative and y non-zero. For y < 0 the Cauchy principal
+// value is returned.
+func rc(x, y float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || math.IsNaN(y) || x < 0 {
+		return math.NaN()
+	}
+	if y == 0 {
+		return math.Inf(1)
+	}
+
+	// The principal value for a negative y is a multiple of the integral
+	// with positive arguments.
+	if y < 0 {
+		if x == 0 {
+			return 0
+		}
+		return math.Sqrt(x/(x-y)) * rc(x-y, -y)
+	}
+	if math.IsInf(x+y, 1) {
+		return 0
+	}
+
+	a0 := (x + 2*y) / 3
+	q := rcScale * math.Abs(a0-x)
+
+	// Apply the duplication theorem.
+	a, fac := a0, 1.0
+	xn, yn := x, y
+	for q >= math.Abs(a) {
+		lambda := 2*math.Sqrt(xn)*math.Sqrt(yn) + yn
+
+		xn = (xn + lambda) / 4
+		yn = (yn + lambda) / 4
+		a = (a + lambda) / 4
+		fac /= 4
+		q /= 4
+	}
+
+	// Evaluate the seventh order expansion in the normalized deviation.
+	s := (y - a0) * fac / a
+	series := 1 + s*s*(3.0/10+s*(1.0/7+s*(3.0/8+s*(9.0/22+s*(159.0/208+s*9.0/8)))))
+	return series / math.Sqrt(a)
+}
+
+// rj computes the Carlson symmetric integral of the third kind.
+//
+//	RJ(x, y, z, p) = 3/2 ∫₀^∞ dt / ((t+p)√((t+x)(t+y)(t+z)))
+//
+// x, y and z must be non-negative with at most one of them zero and p must
+// be non-zero. For p < 0 the Cauchy principal value is returned.
+func rj(x, y, z, p float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsNaN(z) || math.IsNaN(p) || x < 0 || y < 0 || z < 0 {
+		return math.NaN()
+	}
+	if x+y == 0 || y+z == 0 || x+z == 0 || p == 0 {
+		return math.Inf(1)
+	}
+
+	if p < 0 {
+		return rjPV(x, y, z, p)
+	}
+	if math.IsInf(x+y+z+p, 1) {
+		return 0
+	}
+
+	a0 := (x + y + z + 2*p) / 5
+	delta := (p - x) * (p - y) * (p - z)
+	q := rdScale * math.Max(math.Max(math.Abs(a0-x), math.Abs(a0-y)), math.Max(math.Abs(a0-z), math.Abs(a0-p)))
+
+	// Apply the duplication theorem, collecting the contribution of the
+	// pole at every step through RC.
+	a, fac, sum := a0, 1.0, 0.0
+	xn, yn, zn, pn := x, y, z, p
+	for q >= math.Abs(a) {
+		sx, sy, sz, sp := math.Sqrt(xn), math.Sqrt(yn), math.Sqrt(zn), math.Sqrt(pn)
+		lambda := sx*sy + sx*sz + sy*sz
+		d := (sp + sx) * (sp + sy) * (sp + sz)
+		e := fac * fac * fac * delta / (d * d)
+		sum += fac * rc(1, 1+e) / d
+
+		xn = (xn + lambda) / 4
+		yn = (yn + lambda) / 4
+		zn = (zn + lambda) / 4
+		pn = (pn + lambda) / 4
+		a = (a + lambda) / 4
+		fac /= 4
+		q /= 4
+	}
+
+	// Evaluate the fifth order expansion in the normalized deviations.
+	dx := (a0 - x) * fac / a
+	dy := (a0 - y) * fac / a
+	dz := (a0 - z) * fac / a
+	dp := -(dx + dy + dz) / 2
+	e2 := dx*dy + dx*dz + dy*dz - 3*dp*dp
+	e3 := dx*dy*dz + 2*e2*dp + 4*dp*dp*dp
+	e4 := (2*dx*dy*dz + e2*dp + 3*dp*dp*dp) * dp
+	e5 := dx * dy * dz * dp * dp
+
+	series := 1 - 3*e2/14 + e3/6 + 9*e2*e2/88 - 3*e4/22 - 9*e2*e3/52 + 3*e5/26
+	return fac*series/(a*math.Sqrt(a)) + 6*sum
+}
+
+// rjPV computes the Cauchy principal value of RJ for p < 0 by moving the
+// pole to a positive q (DLMF 19.20.14).
+func rjPV(x, y, z, p float64) float64 {
+
+	// Order the arguments so that x <= y <= z.
+	if x > y {
+		x, y = y, x
+	}
+	if y > z {
+		y, z = z, y
+	}
+	if x > y {
+		x, y = y, x
+	}
+
+	q := y + (z-y)*(y-x)/(y-p)
+	return ((q-y)*rj(x, y, z, q) - 3*rf(x, y, z) + 3*rc(x*z/y, p*q/y)) / (y - p)
+}
+
+// rg computes the Carlson symmetric integral RG. With the arguments
+// ordered as x <= y <= z, y is the one kept apart in DLMF 19.21.10,
+//
+//	2RG(x, y, z) = y·RF(x, y, z) + (y - x)(z - y)/3·RD(x, z, y) + √(xz/y)
+//
+// so that every term is non-negative and nothing cancels.
+func rg(x, y, z float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsNaN(z) || x < 0 || y < 0 || z < 0 {
+		return math.NaN()
+	}
+
+	// Order the arguments so that x <= y <= z.
+	if x > y {
+		x, y = y, x
+	}
+	if y > z {
+		y, z = z, y
+	}
+	if x > y {
+		x, y = y, x
+	}
+
+	// With two arguments at zero the integral is elementary, and it grows
+	// without bound with any of them.
+	if y == 0 {
+		return math.Sqrt(z) / 2
+	}
+	if math.IsInf(z, 1) {
+		return math.Inf(1)
+	}
+
+	return (y*rf(x, y, z) + (y-x)*(z-y)/3*rd(x, z, y) + math.Sqrt(x)*math.Sqrt(z)/math.Sqrt(y)) / 2
+}
+
+// RF computes the Carlson symmetric integral of the first kind.
+//
+//	RF(x, y, z) = ½ ∫₀^∞ dt / √((t+x)(t+y)(t+z))
+//
+// It is symmetric in its arguments and homogeneous of degree -1/2, and the
+// Legendre forms follow from it, F(φ|m) = sin φ·RF(cos²φ, 1 - m sin²φ, 1)
+// among them. Unlike those forms it takes the complements as arguments,
+// so nothing cancels as φ approaches π/2 or m approaches 1. x, y and z
+// must be non-negative. When two of them are zero the integral diverges
+// and +Inf is returned, and when any of them is +Inf it is 0.
+func RF(x, y, z float64) float64 {
+	return rf(x, y, z)
+}
+
+// RD computes the Carlson symmetric integral of the second kind.
+//
+//	RD(x, y, z) = 3/2 ∫₀^∞ dt / ((t+z)√((t+x)(t+y)(t+z)))
+//
+// It is RJ(x, y, z, z), symmetric in x and y and homogeneous of degree
+// -3/2. With RF it gives the integrals of the second kind, for instance
+//
+//	E(φ|m) = sin φ·RF(c, d, 1) - m/3·sin³φ·RD(c, d, 1)
+//
+// with c = cos²φ and d = 1 - m sin²φ. x and y must be non-negative and z
+// positive. When z is zero or both x and y are, the integral diverges and
+// +Inf is returned.
+func RD(x, y, z float64) float64 {
+	return rd(x, y, z)
+}
+
+// RDTol computes RD(x, y, z) like RD with the duplication theorem stopped
+// as soon as the series that finishes it is accurate to a relative tol.
+// Each factor of 4⁶ = 4096 in tol saves one duplication step, which pays
+// off where a few digits are enough. A tol below 2⁻⁵³, including 0, is
+// raised to it and gives RD itself. tol must be less than 1; NaN is
+// returned otherwise.
+func RDTol(x, y, z, tol float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(tol) || tol < 0 || tol >= 1 {
+		return math.NaN()
+	}
+
+	return rdScaled(x, y, z, math.Pow(math.Max(tol, carlsonTol)/4, -1.0/6))
+}
+
+// RC computes the degenerate Carlson integral.
+//
+//	RC(x, y) = ½ ∫₀^∞ dt / ((t+y)√(t+x)) = RF(x, y, y)
+//
+// It is homogeneous of degree -1/2 and covers the inverse circular and
+// hyperbolic functions in one form,
+//
+//	atan(t) = t·RC(1, 1+t²),  atanh(t) = t·RC(1, 1-t²),  ln(t) = (t-1)·RC(((1+t)/2)², t)
+//
+// which AtanRC, AtanhRC and LogRC build on. It is an inverse circular
+// function for x < y and an inverse hyperbolic one for x > y. x must be
+// non-negative and y non-zero. For y < 0 the integrand has a pole at
+// t = -y and the Cauchy principal value √(x/(x-y))·RC(x-y, -y) is
+// returned. At y = 0 the integral diverges and +Inf is returned.
+func RC(x, y float64) float64 {
+	return rc(x, y)
+}
+
+// RG computes the Carlson symmetric integral RG.
+//
+//	RG(x, y, z) = 1/(4π) ∫₀^{2π} ∫₀^π √(x sin²θ cos²φ + y sin²θ sin²φ + z cos²θ) sin θ dθ dφ
+//
+// It is symmetric in its arguments, homogeneous of degree 1/2 and the
+// natural form of the quantities that E(m) describes,
+//
+//	E(m) = 2RG(0, 1-m, 1)
+//
+// as well as of the surface area of the ellipsoid with semi-axes a, b and
+// c, S = 4π·abc·RG(1/a², 1/b², 1/c²). It is evaluated from RF and RD as a
+// sum of non-negative terms. x, y and z must be non-negative and RG is
+// +Inf when any of them is.
+func RG(x, y, z float64) float64 {
+	return rg(x, y, z)
+}
+
+// RJ computes the Carlson symmetric integral of the third kind.
+//
+//	RJ(x, y, z, p) = 3/2 ∫₀^∞ dt / ((t+p)√((t+x)(t+y)(t+z)))
+//
+// It is symmetric in x, y and z, homogeneous of degree -3/2 and gives the
+// integrals of the third kind, for instance
+//
+//	Π(n; φ|m) = sin φ·RF(c, d, 1) + n/3·sin³φ·RJ(c, d, 1, 1 - n sin²φ)
+//
+// with c = cos²φ and d = 1 - m sin²φ. x, y and z must be non-negative
+// with at most one of them zero. For p < 0 the integrand has a pole at
+// t = -p and the Cauchy principal value is returned, found from an RJ with
+// a positive p and RC as in Carlson (1995) and DLMF 19.20.14. At p = 0
+// the integral diverges and +Inf is returned. RJPV also reports which of
+// the two was taken.
+func RJ(x, y, z, p float64) float64 {
+	return rj(x, y, z, p)
+}
+
+// RJPV computes RJ(x, y, z, p) like RJ but also reports whether the Cauchy
+// principal value was taken, which happens when p < 0. Arguments outside
+// of the domain return NaN and false.
+func RJPV(x, y, z, p float64) (value float64, pv bool) {
+	value = rj(x, y, z, p)
+	if math.IsNaN(value) {
+		return value, false
+	}
+
+	return value, p < 0
+}
+
+// RF01 computes RF(0, y, 1), the kernel of the complete integral of the
+// first kind, K(m) = RF(0, 1-m, 1). With one argument at 0 and one at 1
+// the duplication theorem reduces to the arithmetic-geometric mean
+//
+//	RF(0, y, 1) = π/(2·AGM(1, √y))
+//
+// which converges quadratically and skips the checks and the series of
+// the general form. y must be non-negative, RF01(0) = +Inf and
+// RF01(+Inf) = 0.
+func RF01(y float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(y) || y < 0 {
+		return math.NaN()
+	}
+
+	switch {
+	case y == 0:
+		return math.Inf(1)
+	case math.IsInf(y, 1):
+		return 0
+	}
+
+	return math.Pi / (2 * AGM(1, math.Sqrt(y)))
+}
+
+// RFSlice computes RF(x[i], y[i], z[i]) for every triple of the parallel
+// slices x, y and z and stores the results in dst, which is allocated when
+// nil. Arguments outside of the domain give NaN in their element only.
+// RFSlice panics if the slices have different lengths.
+func RFSlice(dst, x, y, z []float64) []float64 {
+	if dst == nil {
+		dst = make([]float64, len(x))
+	}
+	if len(dst) != len(x) || len(y) != len(x) || len(z) != len(x) {
+		panic("mathext: slice length mismatch")
+	}
+
+	for i := range dst {
+		dst[i] = rf(x[i], y[i], z[i])
+	}
+
+	return dst
+}
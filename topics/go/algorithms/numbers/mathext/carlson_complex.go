@@ -0,0 +1,266 @@
+package mathext
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// The complex Carlson integrals follow Carlson (1995), which shows that the
+// duplication iterations of the real integrals carry over to complex
+// arguments with principal square roots as long as the arguments stay in
+// the plane cut along the negative real axis. The expansions around the
+// mean are the same polynomials as in the real case.
+
+// onCut reports whether z lies on the closed negative real axis, where
+// the principal square root is discontinuous.
+func onCut(z complex128) bool {
+	return imag(z) == 0 && real(z) < 0
+}
+
+// twoZero reports whether at least two of x, y and z are zero, which makes
+// the integrals with a square root of their product diverge.
+func twoZero(x, y, z complex128) bool {
+	n := 0
+	for _, v := range [...]complex128{x, y, z} {
+		if v == 0 {
+			n++
+		}
+	}
+
+	return n >= 2
+}
+
+// isReal reports whether all of the arguments have a zero imaginary part.
+func isReal(zs ...complex128) bool {
+	for _, z := range zs {
+		if imag(z) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RFComplex computes the Carlson symmetric integral of the first kind for
+// complex arguments.
+//
+//	RF(x, y, z) = ½ ∫₀^∞ dt / √((t+x)(t+y)(t+z))
+//
+// x, y and z must lie off the negative real axis and at most one of them
+// can be zero. For real non-negative arguments it agrees with the real
+// integral.
+func RFComplex(x, y, z complex128) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(x) || cmplx.IsNaN(y) || cmplx.IsNaN(z) || onCut(x) || onCut(y) || onCut(z) {
+		return cmplx.NaN()
+	}
+	if twoZero(x, y, z) {
+		return cmplx.Inf()
+	}
+
+	a0 := (x + y + z) / 3
+	q := rfScale * math.Max(cmplx.Abs(a0-x), math.Max(cmplx.Abs(a0-y), cmplx.Abs(a0-z)))
+
+	// Apply the duplication theorem until the arguments are close enough
+	// for the Taylor expansion around their mean to be exact.
+	a, fac := a0, 1.0
+	xn, yn, zn := x, y, z
+	for q >= cmplx.Abs(a) {
+		sx, sy, sz := cmplx.Sqrt(xn), cmplx.Sqrt(yn), cmplx.Sqrt(zn)
+		lambda := sx*sy + sx*sz + sy*sz
+
+		xn = (xn + lambda) / 4
+		yn = (yn + lambda) / 4
+		zn = (zn + lambda) / 4
+		a = (a + lambda) / 4
+		fac /= 4
+		q /= 4
+	}
+
+	// Evaluate the fifth order expansion in the normalized deviations.
+	f := complex(fac, 0)
+	dx := (a0 - x) * f / a
+	dy := (a0 - y) * f / a
+	dz := -(dx + dy)
+	e2 := dx*dy - dz*dz
+	e3 := dx * dy * dz
+
+	return (1 - e2/10 + e3/14 + e2*e2/24 - 3*e2*e3/44) / cmplx.Sqrt(a)
+}
+
+// RDComplex computes the Carlson symmetric integral of the second kind for
+// complex arguments.
+//
+//	RD(x, y, z) = 3/2 ∫₀^∞ dt / ((t+z)√((t+x)(t+y)(t+z)))
+//
+// x, y and z must lie off the negative real axis, at most one of x and y
+// can be zero and z must not be zero.
+func RDComplex(x, y, z complex128) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(x) || cmplx.IsNaN(y) || cmplx.IsNaN(z) || onCut(x) || onCut(y) || onCut(z) {
+		return cmplx.NaN()
+	}
+	if z == 0 || (x == 0 && y == 0) {
+		return cmplx.Inf()
+	}
+
+	a0 := (x + y + 3*z) / 5
+	q := rdScale * math.Max(cmplx.Abs(a0-x), math.Max(cmplx.Abs(a0-y), cmplx.Abs(a0-z)))
+
+	// Apply the duplication theorem, collecting the contribution of the
+	// z argument at every step.
+	a, fac := a0, 1.0
+	var sum complex128
+	xn, yn, zn := x, y, z
+	for q >= cmplx.Abs(a) {
+		sx, sy, sz := cmplx.Sqrt(xn), cmplx.Sqrt(yn), cmplx.Sqrt(zn)
+		lambda := sx*sy + sx*sz + sy*sz
+		sum += complex(fac, 0) / (sz * (zn + lambda))
+
+		xn = (xn + lambda) / 4
+		yn = (yn + lambda) / 4
+		zn = (zn + lambda) / 4
+		a = (a + lambda) / 4
+		fac /= 4
+		q /= 4
+	}
+
+	// Evaluate the fifth order expansion in the normalized deviations.
+	f := complex(fac, 0)
+	dx := (a0 - x) * f / a
+	dy := (a0 - y) * f / a
+	dz := -(dx + dy) / 3
+	xy, z2 := dx*dy, dz*dz
+	e2 := xy - 6*z2
+	e3 := (3*xy - 8*z2) * dz
+	e4 := 3 * (xy - z2) * z2
+	e5 := xy * z2 * dz
+
+	series := 1 - 3*e2/14 + e3/6 + 9*e2*e2/88 - 3*e4/22 - 9*e2*e3/52 + 3*e5/26
+	return f*series/(a*cmplx.Sqrt(a)) + 3*sum
+}
+
+// RCComplex computes the degenerate Carlson integral for complex
+// arguments.
+//
+//	RC(x, y) = ½ ∫₀^∞ dt / ((t+y)√(t+x))
+//
+// x must lie off the negative real axis and y must not be zero. As in the
+// real case, a negative real y gives the Cauchy principal value
+//
+//	RC(x, y) = √(x/(x-y))·RC(x-y, -y)
+//
+// which is the mean of the limits from above and below the cut. They
+// differ from it by ∓iπ/(2√(x-y)).
+func RCComplex(x, y complex128) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(x) || cmplx.IsNaN(y) || onCut(x) {
+		return cmplx.NaN()
+	}
+	if y == 0 {
+		return cmplx.Inf()
+	}
+
+	// The principal value for a negative real y is a multiple of the
+	// integral with arguments off the cut.
+	if onCut(y) {
+		if x == 0 {
+			return 0
+		}
+		return cmplx.Sqrt(x/(x-y)) * RCComplex(x-y, -y)
+	}
+
+	a0 := (x + 2*y) / 3
+	q := rcScale * cmplx.Abs(a0-x)
+
+	// Apply the duplication theorem.
+	a, fac := a0, 1.0
+	xn, yn := x, y
+	for q >= cmplx.Abs(a) {
+		lambda := 2*cmplx.Sqrt(xn)*cmplx.Sqrt(yn) + yn
+
+		xn = (xn + lambda) / 4
+		yn = (yn + lambda) / 4
+		a = (a + lambda) / 4
+		fac /= 4
+		q /= 4
+	}
+
+	// Evaluate the seventh order expansion in the normalized deviation.
+	s := (y - a0) * complex(fac, 0) / a
+	series := 1 + s*s*(3.0/10+s*(1.0/7+s*(3.0/8+s*(9.0/22+s*(159.0/208+s*9.0/8)))))
+	return series / cmplx.Sqrt(a)
+}
+
+// RJComplex computes the Carlson symmetric integral of the third kind for
+// complex arguments.
+//
+//	RJ(x, y, z, p) = 3/2 ∫₀^∞ dt / ((t+p)√((t+x)(t+y)(t+z)))
+//
+// x, y, z and p must lie off the negative real axis, at most one of x, y
+// and z can be zero and p must not be zero. Carlson (1995) proves that the
+// iteration lands on the right branch when x, y and z are real and
+// non-negative, or when one of them is real and non-negative and the
+// other two are complex conjugates; other combinations can converge to a
+// different branch. As in the real case, a negative real p with real x, y
+// and z gives the Cauchy principal value. With complex x, y or z and p on
+// the negative axis the limits from either side differ by a residue and
+// NaN is returned.
+func RJComplex(x, y, z, p complex128) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(x) || cmplx.IsNaN(y) || cmplx.IsNaN(z) || cmplx.IsNaN(p) || onCut(x) || onCut(y) || onCut(z) {
+		return cmplx.NaN()
+	}
+	if twoZero(x, y, z) || p == 0 {
+		return cmplx.Inf()
+	}
+	if onCut(p) {
+		if !isReal(x, y, z) {
+			return cmplx.NaN()
+		}
+		return complex(rjPV(real(x), real(y), real(z), real(p)), 0)
+	}
+
+	a0 := (x + y + z + 2*p) / 5
+	delta := (p - x) * (p - y) * (p - z)
+	q := rdScale * math.Max(math.Max(cmplx.Abs(a0-x), cmplx.Abs(a0-y)), math.Max(cmplx.Abs(a0-z), cmplx.Abs(a0-p)))
+
+	// Apply the duplication theorem, collecting the contribution of the
+	// pole at every step through RC.
+	a, fac := a0, 1.0
+	var sum complex128
+	xn, yn, zn, pn := x, y, z, p
+	for q >= cmplx.Abs(a) {
+		sx, sy, sz, sp := cmplx.Sqrt(xn), cmplx.Sqrt(yn), cmplx.Sqrt(zn), cmplx.Sqrt(pn)
+		lambda := sx*sy + sx*sz + sy*sz
+		d := (sp + sx) * (sp + sy) * (sp + sz)
+		e := complex(fac*fac*fac, 0) * delta / (d * d)
+		sum += complex(fac, 0) * RCComplex(1, 1+e) / d
+
+		xn = (xn + lambda) / 4
+		yn = (yn + lambda) / 4
+		zn = (zn + lambda) / 4
+		pn = (pn + lambda) / 4
+		a = (a + lambda) / 4
+		fac /= 4
+		q /= 4
+	}
+
+	// Evaluate the fifth order expansion in the normalized deviations.
+	f := complex(fac, 0)
+	dx := (a0 - x) * f / a
+	dy := (a0 - y) * f / a
+	dz := (a0 - z) * f / a
+	dp := -(dx + dy + dz) / 2
+	e2 := dx*dy + dx*dz + dy*dz - 3*dp*dp
+	e3 := dx*dy*dz + 2*e2*dp + 4*dp*dp*dp
+	e4 := (2*dx*dy*dz + e2*dp + 3*dp*dp*dp) * dp
+	e5 := dx * dy * dz * dp * dp
+
+	series := 1 - 3*e2/14 + e3/6 + 9*e2*e2/88 - 3*e4/22 - 9*e2*e3/52 + 3*e5/26
+	return f*series/(a*cmplx.Sqrt(a)) + 6*sum
+}
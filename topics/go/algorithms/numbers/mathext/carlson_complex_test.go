@@ -0,0 +1,120 @@
+package mathext_test
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestCarlsonComplex(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func() complex128
+		want complex128
+	}{
+		{"RF(i,-i,0)", func() complex128 { return mathext.RFComplex(1i, -1i, 0) }, 1.8540746773013719},
+		{"RF(i-1,i,0)", func() complex128 { return mathext.RFComplex(-1+1i, 1i, 0) }, 0.79612586584234 - 1.2138566698365i},
+		{"RF(i,-i,2)", func() complex128 { return mathext.RFComplex(1i, -1i, 2) }, 1.0441445654064},
+		{"RF(i-1,i,1-i)", func() complex128 { return mathext.RFComplex(-1+1i, 1i, 1-1i) }, 0.93912050218619 - 0.53296252018635i},
+		{"RC(0,i)", func() complex128 { return mathext.RCComplex(0, 1i) }, 1.1107207345396 - 1.1107207345396i},
+		{"RC(-i,i)", func() complex128 { return mathext.RCComplex(-1i, 1i) }, 1.2260849569072 - 0.34471136988768i},
+		{"RC(i,-1)", func() complex128 { return mathext.RCComplex(1i, -1) }, 0.77778596920447 + 0.19832484993429i},
+		{"RJ(2,3,4,i-1)", func() complex128 { return mathext.RJComplex(2, 3, 4, -1+1i) }, 0.13613945827771 - 0.38207561624427i},
+		{"RJ(i,-i,0,2)", func() complex128 { return mathext.RJComplex(1i, -1i, 0, 2) }, 1.6490011662711},
+		{"RJ(i-1,-i-1,1,2)", func() complex128 { return mathext.RJComplex(-1+1i, -1-1i, 1, 2) }, 0.94148358841220},
+		{"RJ(i,-i,0,1-i)", func() complex128 { return mathext.RJComplex(1i, -1i, 0, 1-1i) }, 1.8260115229009 + 1.2290661908643i},
+		{"RJ(i-1,-i-1,1,i-3)", func() complex128 { return mathext.RJComplex(-1+1i, -1-1i, 1, -3+1i) }, -0.61127970812028 - 1.0684038390007i},
+		{"RD(i,-i,2)", func() complex128 { return mathext.RDComplex(1i, -1i, 2) }, 0.65933854154220},
+		{"RD(0,i,-i)", func() complex128 { return mathext.RDComplex(0, 1i, -1i) }, 1.2708196271910 + 2.7811120159521i},
+		{"RD(0,i-1,i)", func() complex128 { return mathext.RDComplex(0, -1+1i, 1i) }, -1.8577235439239 - 0.96193450888839i},
+		{"RD(-2-i,-i,i-1)", func() complex128 { return mathext.RDComplex(-2-1i, -1i, -1+1i) }, 1.8249027393704 - 1.2218475784827i},
+	}
+
+	t.Log("Given the need to evaluate the Carlson integrals for complex arguments.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking %s against Carlson (1995).", testID, test.name)
+				{
+					got := test.f()
+					if e := cmplx.Abs(got-test.want) / cmplx.Abs(test.want); e > 1e-13 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCarlsonComplexReal(t *testing.T) {
+	t.Log("Given the need to reduce the complex Carlson integrals to the real ones.")
+	{
+		t.Logf("\tTest 0:\tWhen building the complete integrals from real arguments.")
+		{
+			for _, m := range []float64{0, 0.3, 0.8, 0.99} {
+				mc := complex(1-m, 0)
+				k, d := mathext.CompleteK(m), mathext.CompleteD(m)
+				if got := mathext.RFComplex(0, mc, 1); imag(got) != 0 || relErr(real(got), k) > 4e-16 {
+					t.Fatalf("\t%s\tTest 0:\tShould get RF = K(%v) = %v, got %v.", failed, m, k, got)
+				}
+				if got := mathext.RDComplex(0, mc, 1); imag(got) != 0 || relErr(real(got)/3, d) > 1e-15 {
+					t.Fatalf("\t%s\tTest 0:\tShould get RD = 3D(%v) = %v, got %v.", failed, m, 3*d, got)
+				}
+				const n = 0.4
+				want := 3 * (mathext.CompletePi(n, m) - k) / n
+				if got := mathext.RJComplex(0, mc, 1, 1-n); imag(got) != 0 || relErr(real(got), want) > 1e-14 {
+					t.Fatalf("\t%s\tTest 0:\tShould get RJ = %v at m=%v, got %v.", failed, want, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match K, D and Π.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking RC and the principal values.")
+		{
+			if got, want := mathext.RCComplex(0.25, 1), math.Acos(0.5)/math.Sqrt(0.75); relErr(real(got), want) > 4e-16 || imag(got) != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get RC(1/4, 1) = %v, got %v.", failed, want, got)
+			}
+			if got, want := mathext.RCComplex(0.25, -2), math.Ln2/3; relErr(real(got), want) > 4e-16 || imag(got) != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get RC(1/4, -2) = ln 2/3, got %v.", failed, got)
+			}
+			want, _ := mathext.RJPV(2, 3, 4, -0.5)
+			if got := mathext.RJComplex(2, 3, 4, -0.5); real(got) != want || imag(got) != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get the principal value %v, got %v.", failed, want, got)
+			}
+			if got := mathext.RJComplex(1i, -1i, 1, -0.5); !cmplx.IsNaN(got) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN for a complex principal value, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get the real values and principal values.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen all of the arguments are equal.")
+		{
+			for _, x := range []complex128{2, 1i, -1 + 2i, 0.3 - 4i} {
+				r := 1 / cmplx.Sqrt(x)
+				r3 := r * r * r
+				if cmplx.Abs(mathext.RFComplex(x, x, x)-r) > 1e-15*cmplx.Abs(r) || cmplx.Abs(mathext.RCComplex(x, x)-r) > 1e-15*cmplx.Abs(r) {
+					t.Fatalf("\t%s\tTest 2:\tShould get RF(x,x,x) = RC(x,x) = x^(-1/2) at x=%v.", failed, x)
+				}
+				if cmplx.Abs(mathext.RDComplex(x, x, x)-r3) > 1e-15*cmplx.Abs(r3) || cmplx.Abs(mathext.RJComplex(x, x, x, x)-r3) > 1e-15*cmplx.Abs(r3) {
+					t.Fatalf("\t%s\tTest 2:\tShould get RD(x,x,x) = RJ(x,x,x,x) = x^(-3/2) at x=%v.", failed, x)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get x^(-1/2) and x^(-3/2).", succeed)
+		}
+
+		t.Logf("\tTest 3:\tWhen an argument lies on the cut.")
+		{
+			if got := mathext.RFComplex(-1, 1i, 2); !cmplx.IsNaN(got) {
+				t.Fatalf("\t%s\tTest 3:\tShould get NaN for a negative real argument, got %v.", failed, got)
+			}
+			if got := mathext.RFComplex(0, 0, 1i); !cmplx.IsInf(got) {
+				t.Fatalf("\t%s\tTest 3:\tShould get Inf for two zero arguments, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 3:\tShould reject arguments on the cut.", succeed)
+		}
+	}
+}
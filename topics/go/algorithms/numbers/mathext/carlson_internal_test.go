@@ -0,0 +1,157 @@
+package mathext
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRF01(t *testing.T) {
+	t.Log("Given the need to evaluate RF(0, y, 1) with the AGM.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with the general RF.")
+		{
+			for _, y := range []float64{1e-20, 1e-8, 1e-3, 0.1, 0.25, 0.5, 0.75, 0.9, 0.999, 1, 4, 1e10, 1e300} {
+				got, want := RF01(y), rf(0, y, 1)
+				if math.Abs(got-want) > 8*math.Abs(math.Nextafter(want, 0)-want) {
+					t.Fatalf("\t✗\tTest 0:\tShould get RF(0, %v, 1) = %v, got %v.", y, want, got)
+				}
+			}
+			for i := 1; i <= 1000; i++ {
+				y := float64(i) / 1000
+				got, want := RF01(y), rf(0, y, 1)
+				if math.Abs(got-want) > 8*math.Abs(math.Nextafter(want, 0)-want) {
+					t.Fatalf("\t✗\tTest 0:\tShould get RF(0, %v, 1) = %v, got %v.", y, want, got)
+				}
+			}
+			t.Logf("\t✓\tTest 0:\tShould agree with RF to eight ulp.")
+		}
+
+		t.Logf("\tTest 1:\tWhen comparing with 60 digit values.")
+		{
+			refs := []struct {
+				y, want float64
+			}{
+				{1e-300, 346.77405831022674},
+				{1e-8, 10.59663475708766},
+				{0.006, 3.948722823570299},
+				{0.1, 2.5780921133481733},
+				{0.415, 1.933683012485459},
+				{0.5, 1.8540746773013719},
+				{0.9, 1.6124413487202194},
+				{4, 1.0782578237498217},
+			}
+			for _, r := range refs {
+				if got := RF01(r.y); math.Abs(got-r.want) > 2*math.Abs(math.Nextafter(r.want, 0)-r.want) {
+					t.Fatalf("\t✗\tTest 1:\tShould get RF(0, %v, 1) = %v, got %v.", r.y, r.want, got)
+				}
+			}
+			t.Logf("\t✓\tTest 1:\tShould get the reference values to two ulp.")
+		}
+
+		t.Logf("\tTest 2:\tWhen checking the edges of the domain.")
+		{
+			if got := RF01(0); !math.IsInf(got, 1) {
+				t.Fatalf("\t✗\tTest 2:\tShould get +Inf at y=0, got %v.", got)
+			}
+			if got := RF01(math.Inf(1)); got != 0 {
+				t.Fatalf("\t✗\tTest 2:\tShould get 0 at y=+Inf, got %v.", got)
+			}
+			if got := RF01(-1); !math.IsNaN(got) {
+				t.Fatalf("\t✗\tTest 2:\tShould get NaN at y=-1, got %v.", got)
+			}
+			t.Logf("\t✓\tTest 2:\tShould handle the edges.")
+		}
+	}
+}
+
+var rf01 float64
+
+func BenchmarkRF01(b *testing.B) {
+	v := 0.3
+	for i := 0; i < b.N; i++ {
+		v = RF01(0.3 + 0*v)
+	}
+	rf01 = v
+}
+
+func BenchmarkRF(b *testing.B) {
+	v := 0.3
+	for i := 0; i < b.N; i++ {
+		v = rf(0, 0.3+0*v, 1)
+	}
+	rf01 = v
+}
+
+func TestRFSlice(t *testing.T) {
+	t.Log("Given the need to evaluate RF over arrays of triples.")
+	{
+		x := []float64{0, 0.5, 1, 2, 1e-10, 3, -1, math.NaN(), 0}
+		y := []float64{1, 0.5, 2, 3, 1, 1e10, 1, 1, 0}
+		z := []float64{2, 0.5, 4, 0, 1, 7, 1, 1, 1}
+
+		t.Logf("\tTest 0:\tWhen comparing with rf per element.")
+		{
+			got := RFSlice(nil, x, y, z)
+			for i := range x {
+				if want := rf(x[i], y[i], z[i]); got[i] != want && !(math.IsNaN(got[i]) && math.IsNaN(want)) {
+					t.Fatalf("\t✗\tTest 0:\tShould get RF(%v, %v, %v) = %v, got %v.", x[i], y[i], z[i], want, got[i])
+				}
+			}
+			dst := make([]float64, len(x))
+			if got := RFSlice(dst, x, y, z); &got[0] != &dst[0] {
+				t.Fatalf("\t✗\tTest 0:\tShould fill and return dst.")
+			}
+			t.Logf("\t✓\tTest 0:\tShould agree with rf element by element.")
+		}
+
+		t.Logf("\tTest 1:\tWhen the lengths differ.")
+		{
+			for _, f := range []func(){
+				func() { RFSlice(make([]float64, 2), x, y, z) },
+				func() { RFSlice(nil, x, y[:3], z) },
+				func() { RFSlice(nil, x, y, z[:3]) },
+			} {
+				msg := func() (msg interface{}) {
+					defer func() { msg = recover() }()
+					f()
+					return nil
+				}()
+				if msg != "mathext: slice length mismatch" {
+					t.Fatalf("\t✗\tTest 1:\tShould panic on the length mismatch, got %v.", msg)
+				}
+			}
+			t.Logf("\t✓\tTest 1:\tShould panic.")
+		}
+	}
+}
+
+// rfTriples returns n triples spread over the domain of RF.
+func rfTriples(n int) (x, y, z []float64) {
+	x, y, z = make([]float64, n), make([]float64, n), make([]float64, n)
+	for i := range x {
+		s := float64(i) / float64(n)
+		x[i], y[i], z[i] = s, 1+s, 2+3*s
+	}
+
+	return x, y, z
+}
+
+func BenchmarkRFSlice(b *testing.B) {
+	x, y, z := rfTriples(1024)
+	dst := make([]float64, len(x))
+	for i := 0; i < b.N; i++ {
+		RFSlice(dst, x, y, z)
+	}
+	rf01 = dst[0]
+}
+
+func BenchmarkRFLoop(b *testing.B) {
+	x, y, z := rfTriples(1024)
+	dst := make([]float64, len(x))
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j] = rf(x[j], y[j], z[j])
+		}
+	}
+	rf01 = dst[0]
+}
@@ -0,0 +1,437 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestRJPV(t *testing.T) {
+	tt := []struct {
+		name       string
+		x, y, z, p float64
+		want       float64
+		pv         bool
+	}{
+		{"positive", 2, 3, 4, 5, 0.14297579667156754, false},
+		{"zero", 0, 1, 2, 3, 0.7768862377858233, false},
+		{"negative", 2, 3, 4, -0.5, 0.24723819703051564, true},
+		{"negativeFar", 2, 3, 4, -5, -0.1271123004296391, true},
+		{"negativeZero", 0, 1, 2, -0.25, -2.4052965050987023, true},
+	}
+
+	t.Log("Given the need to report the principal value branch of RJ.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking RJ(%v, %v, %v, %v).", testID, test.x, test.y, test.z, test.p)
+				{
+					got, pv := mathext.RJPV(test.x, test.y, test.z, test.p)
+					if e := relErr(got, test.want); e > 1e-14 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+
+					if pv != test.pv || pv != (test.p < 0) {
+						t.Fatalf("\t%s\tTest %d:\tShould report pv %v, got %v.", failed, testID, test.pv, pv)
+					}
+					t.Logf("\t%s\tTest %d:\tShould report pv %v.", succeed, testID, test.pv)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestRJPVDomain(t *testing.T) {
+	t.Log("Given the need to reject arguments outside of the RJ domain.")
+	{
+		t.Logf("\tTest 0:\tWhen passing a negative x.")
+		{
+			got, pv := mathext.RJPV(-1, 1, 2, -3)
+			if !math.IsNaN(got) || pv {
+				t.Fatalf("\t%s\tTest 0:\tShould get NaN and false, got %v and %v.", failed, got, pv)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get NaN and false.", succeed)
+		}
+	}
+}
+
+func TestRF(t *testing.T) {
+	tt := []struct {
+		name    string
+		x, y, z float64
+		want    float64
+	}{
+		{"lemniscate", 1, 2, 0, 1.3110287771460598},
+		{"completeK", 0.5, 1, 0, 1.8540746773013719},
+		{"general", 2, 3, 4, 0.5840828416771517},
+		{"equal", 4, 4, 4, 0.5},
+		{"tiny", 1e-300, 1, 2, 1.3110287771460598},
+		{"wide", 1e300, 1e300, 1e-300, 1.5707963267948966e-150},
+	}
+
+	t.Log("Given the need to evaluate the Carlson integral of the first kind.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking RF(%v, %v, %v).", testID, test.x, test.y, test.z)
+				{
+					got := mathext.RF(test.x, test.y, test.z)
+					if math.Abs(got-test.want) > 2*ulp(test.want) {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v.", failed, testID, test.want, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+
+					for _, p := range [][3]float64{{test.y, test.z, test.x}, {test.z, test.x, test.y}, {test.y, test.x, test.z}} {
+						if e := relErr(mathext.RF(p[0], p[1], p[2]), got); e > 4e-16 {
+							t.Fatalf("\t%s\tTest %d:\tShould be symmetric, got RF(%v, %v, %v) off by %g.", failed, testID, p[0], p[1], p[2], e)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould be symmetric.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen arguments are zero, infinite or outside of the domain.", len(tt))
+		{
+			if got := mathext.RF(0, 0, 1); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get +Inf for two zeros, got %v.", failed, len(tt), got)
+			}
+			if got := mathext.RF(1, math.Inf(1), 2); got != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 0 for an infinite argument, got %v.", failed, len(tt), got)
+			}
+			for _, a := range [][3]float64{{-1, 1, 1}, {1, math.NaN(), 1}, {1, 1, -0.5}} {
+				if got := mathext.RF(a[0], a[1], a[2]); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould get NaN for %v, got %v.", failed, len(tt), a, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould get +Inf, 0 and NaN.", succeed, len(tt))
+		}
+	}
+}
+
+func TestRD(t *testing.T) {
+	tt := []struct {
+		name    string
+		x, y, z float64
+		want    float64
+	}{
+		{"zeroX", 0, 2, 1, 1.7972103521033884},
+		{"general", 2, 3, 4, 0.16510527294261054},
+		{"threeQuarterPi", 0, 1, 1, 2.356194490192345},
+		{"equal", 1, 1, 1, 1},
+		{"tiny", 1e-300, 2, 1, 1.7972103521033884},
+		{"smallZ", 0.5, 2, 1e-3, 89.95731388987475},
+	}
+
+	t.Log("Given the need to evaluate the Carlson integral of the second kind.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking RD(%v, %v, %v).", testID, test.x, test.y, test.z)
+				{
+					got := mathext.RD(test.x, test.y, test.z)
+					if math.Abs(got-test.want) > 2*ulp(test.want) {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v.", failed, testID, test.want, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+
+					if e := relErr(mathext.RD(test.y, test.x, test.z), got); e > 4e-16 {
+						t.Fatalf("\t%s\tTest %d:\tShould be symmetric in x and y, off by %g.", failed, testID, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould be symmetric in x and y.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen arguments are zero or outside of the domain.", len(tt))
+		{
+			for _, a := range [][3]float64{{1, 2, 0}, {0, 0, 1}} {
+				if got := mathext.RD(a[0], a[1], a[2]); !math.IsInf(got, 1) {
+					t.Fatalf("\t%s\tTest %d:\tShould get +Inf for %v, got %v.", failed, len(tt), a, got)
+				}
+			}
+			for _, a := range [][3]float64{{-1, 1, 1}, {1, 1, math.NaN()}, {1, 1, -1}} {
+				if got := mathext.RD(a[0], a[1], a[2]); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould get NaN for %v, got %v.", failed, len(tt), a, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould get +Inf and NaN.", succeed, len(tt))
+		}
+	}
+}
+
+func TestRDTol(t *testing.T) {
+	args := [][3]float64{{0, 2, 1}, {2, 3, 4}, {0.5, 2, 1e-3}, {1e-6, 1e3, 1}, {10, 0, 1e-4}}
+
+	t.Log("Given the need to trade precision of RD for speed.")
+	{
+		t.Logf("\tTest 0:\tWhen asking for a relative error target.")
+		{
+			for _, tol := range []float64{0.5, 1e-3, 1e-8, 1e-12} {
+				for _, a := range args {
+					want := mathext.RD(a[0], a[1], a[2])
+					if got := mathext.RDTol(a[0], a[1], a[2], tol); relErr(got, want) > tol {
+						t.Fatalf("\t%s\tTest 0:\tShould get RD%v = %v to %v, got %v.", failed, a, want, tol, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould stay within the target.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen the target is below the precision of float64.")
+		{
+			for _, a := range args {
+				want := mathext.RD(a[0], a[1], a[2])
+				for _, tol := range []float64{0, 1e-300, 0x1p-53} {
+					if got := mathext.RDTol(a[0], a[1], a[2], tol); got != want {
+						t.Fatalf("\t%s\tTest 1:\tShould get RD%v = %v exactly at tol %v, got %v.", failed, a, want, tol, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould match RD.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen the target is outside of [0, 1).")
+		{
+			for _, tol := range []float64{-1e-3, 1, math.Inf(1), math.NaN()} {
+				if got := mathext.RDTol(2, 3, 4, tol); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest 2:\tShould get NaN at tol %v, got %v.", failed, tol, got)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get NaN.", succeed)
+		}
+	}
+}
+
+func TestRJ(t *testing.T) {
+	tt := []struct {
+		name       string
+		x, y, z, p float64
+		want       float64
+	}{
+		{"positive", 2, 3, 4, 5, 0.14297579667156754},
+		{"zero", 0, 1, 2, 3, 0.7768862377858233},
+		{"negative", 2, 3, 4, -0.5, 0.24723819703051564},
+		{"negativeFar", 2, 3, 4, -5, -0.1271123004296391},
+	}
+
+	t.Log("Given the need to evaluate the Carlson integral of the third kind.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking RJ(%v, %v, %v, %v).", testID, test.x, test.y, test.z, test.p)
+				{
+					got := mathext.RJ(test.x, test.y, test.z, test.p)
+					if e := relErr(got, test.want); e > 1e-14 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+
+					for _, a := range [][3]float64{{test.y, test.z, test.x}, {test.z, test.y, test.x}} {
+						if e := relErr(mathext.RJ(a[0], a[1], a[2], test.p), got); e > 1e-15 {
+							t.Fatalf("\t%s\tTest %d:\tShould be symmetric in x, y and z, off by %g.", failed, testID, e)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould be symmetric in x, y and z.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen p is z.", len(tt))
+		{
+			for _, a := range [][3]float64{{0, 2, 1}, {2, 3, 4}, {0.5, 2, 1e-3}} {
+				if e := relErr(mathext.RJ(a[0], a[1], a[2], a[2]), mathext.RD(a[0], a[1], a[2])); e > 1e-15 {
+					t.Fatalf("\t%s\tTest %d:\tShould match RD%v, off by %g.", failed, len(tt), a, e)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould match RD.", succeed, len(tt))
+		}
+
+		// At m = 0 the integral of the third kind is elementary. For
+		// n > 1 and t = tan φ
+		//
+		//	Π(n; φ|0) = ln|(1 + √(n-1)·t)/(1 - √(n-1)·t)| / (2√(n-1))
+		//
+		// which is a principal value once t passes 1/√(n-1), where
+		// p = 1 - n sin²φ turns negative.
+		t.Logf("\tTest %d:\tWhen p is negative.", len(tt)+1)
+		{
+			for _, n := range []float64{1.5, 3, 40} {
+				for _, phi := range []float64{0.9, 1.2, 1.5} {
+					s, c := math.Sincos(phi)
+					r := math.Sqrt(n-1) * math.Tan(phi)
+					want := math.Log(math.Abs((1+r)/(1-r))) / (2 * math.Sqrt(n-1))
+					got := s*mathext.RF(c*c, 1, 1) + n/3*s*s*s*mathext.RJ(c*c, 1, 1, 1-n*s*s)
+					if math.Abs(got-want) > 1e-14*math.Max(1, math.Abs(want)) {
+						t.Fatalf("\t%s\tTest %d:\tShould get Π(%v; %v|0) = %v, got %v.", failed, len(tt)+1, n, phi, want, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould get the principal value.", succeed, len(tt)+1)
+		}
+
+		t.Logf("\tTest %d:\tWhen arguments are zero or outside of the domain.", len(tt)+2)
+		{
+			if got := mathext.RJ(1, 2, 3, 0); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get +Inf at p = 0, got %v.", failed, len(tt)+2, got)
+			}
+			for _, a := range [][4]float64{{-1, 1, 1, 1}, {1, 1, 1, math.NaN()}} {
+				if got := mathext.RJ(a[0], a[1], a[2], a[3]); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould get NaN for %v, got %v.", failed, len(tt)+2, a, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould get +Inf and NaN.", succeed, len(tt)+2)
+		}
+	}
+}
+
+func TestRC(t *testing.T) {
+	tt := []struct {
+		name string
+		x, y float64
+		want float64
+	}{
+		{"pi", 0, 0.25, math.Pi},
+		{"ln2", 2.25, 2, math.Ln2},
+		{"principalValue", 0.25, -2, math.Ln2 / 3},
+		{"equal", 4, 4, 0.5},
+		{"circular", 1, 2, math.Pi / 4},
+		{"hyperbolic", 1, 0.75, math.Log(3)},
+	}
+
+	t.Log("Given the need to evaluate the degenerate Carlson integral.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking RC(%v, %v).", testID, test.x, test.y)
+				{
+					got := mathext.RC(test.x, test.y)
+					if math.Abs(got-test.want) > 2*ulp(test.want) {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v.", failed, testID, test.want, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+
+					if got := mathext.RC(4*test.x, 4*test.y); math.Abs(got-test.want/2) > 2*ulp(test.want/2) {
+						t.Fatalf("\t%s\tTest %d:\tShould be homogeneous of degree -1/2, got %v.", failed, testID, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould be homogeneous of degree -1/2.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen matching RF(x, y, y).", len(tt))
+		{
+			for _, a := range [][2]float64{{0, 0.25}, {2.25, 2}, {1e-3, 1e3}, {1e3, 1e-3}} {
+				if e := relErr(mathext.RC(a[0], a[1]), mathext.RF(a[0], a[1], a[1])); e > 1e-15 {
+					t.Fatalf("\t%s\tTest %d:\tShould match RF at %v, off by %g.", failed, len(tt), a, e)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould match RF.", succeed, len(tt))
+		}
+
+		t.Logf("\tTest %d:\tWhen arguments are zero, infinite or outside of the domain.", len(tt)+1)
+		{
+			if got := mathext.RC(1, 0); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get +Inf at y = 0, got %v.", failed, len(tt)+1, got)
+			}
+			if got := mathext.RC(math.Inf(1), 1); got != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 0 at x = +Inf, got %v.", failed, len(tt)+1, got)
+			}
+			for _, a := range [][2]float64{{-1, 1}, {math.NaN(), 1}, {1, math.NaN()}} {
+				if got := mathext.RC(a[0], a[1]); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould get NaN for %v, got %v.", failed, len(tt)+1, a, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould get +Inf, 0 and NaN.", succeed, len(tt)+1)
+		}
+	}
+}
+
+func TestRG(t *testing.T) {
+	tt := []struct {
+		name    string
+		x, y, z float64
+		want    float64
+	}{
+		{"pi", 0, 16, 16, 3.141592653589793},
+		{"general", 2, 3, 4, 1.7255030280692278},
+		{"zeroX", 0, 0.0796, 4, 1.028475809028804},
+		{"tiny", 1e-300, 1, 2, 0.955049447256928},
+		{"twoZeros", 0, 0, 4, 1},
+		{"equal", 1, 1, 1, 1},
+		{"wide", 0.5, 1e6, 1e-6, 500.0010175802406},
+	}
+
+	t.Log("Given the need to evaluate the Carlson integral RG.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking RG(%v, %v, %v).", testID, test.x, test.y, test.z)
+				{
+					got := mathext.RG(test.x, test.y, test.z)
+					if math.Abs(got-test.want) > 2*ulp(test.want) {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v.", failed, testID, test.want, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+
+					for _, a := range [][3]float64{{test.y, test.z, test.x}, {test.z, test.x, test.y}, {test.y, test.x, test.z}} {
+						if got2 := mathext.RG(a[0], a[1], a[2]); got2 != got {
+							t.Fatalf("\t%s\tTest %d:\tShould be symmetric, got RG(%v, %v, %v) = %v.", failed, testID, a[0], a[1], a[2], got2)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould be symmetric.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen expressing E(m).", len(tt))
+		{
+			for _, m := range []float64{0, 0.1, 0.5, 0.9, 0.999999, 1} {
+				want := mathext.CompleteE(m)
+				if got := 2 * mathext.RG(0, 1-m, 1); math.Abs(got-want) > 4*ulp(want) {
+					t.Fatalf("\t%s\tTest %d:\tShould get E(%v) = %v, got %v.", failed, len(tt), m, want, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould match CompleteE.", succeed, len(tt))
+		}
+
+		// The oblate spheroid with semi-axes a = b and c < a has the area
+		// 2πa²(1 + (1-e²)/e·atanh(e)) with e² = 1 - c²/a².
+		t.Logf("\tTest %d:\tWhen computing the surface area of spheroids.", len(tt)+1)
+		{
+			for _, c := range []float64{2, 1, 0.1} {
+				a := 2.0
+				want := 4 * math.Pi * a * a
+				if c < a {
+					e := math.Sqrt(1 - c*c/(a*a))
+					want = 2 * math.Pi * a * a * (1 + (1-e*e)/e*math.Atanh(e))
+				}
+				if got := 4 * math.Pi * a * a * c * mathext.RG(1/(a*a), 1/(a*a), 1/(c*c)); relErr(got, want) > 1e-15 {
+					t.Fatalf("\t%s\tTest %d:\tShould get the area %v at c=%v, got %v.", failed, len(tt)+1, want, c, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould get the closed form areas.", succeed, len(tt)+1)
+		}
+
+		t.Logf("\tTest %d:\tWhen arguments are infinite or outside of the domain.", len(tt)+2)
+		{
+			if got := mathext.RG(1, math.Inf(1), 2); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get +Inf, got %v.", failed, len(tt)+2, got)
+			}
+			for _, a := range [][3]float64{{-1, 1, 1}, {1, math.NaN(), 1}} {
+				if got := mathext.RG(a[0], a[1], a[2]); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould get NaN for %v, got %v.", failed, len(tt)+2, a, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould get +Inf and NaN.", succeed, len(tt)+2)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestComplete32(t *testing.T) {
+	const tol = 2e-7
+
+	t.Log("Given the need to evaluate the complete integrals natively in float32.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with the float64 functions across [0, 1).")
+		{
+			for i := 0; i < 4096; i++ {
+				m := float32(i) / 4096
+				if e := relErr(float64(mathext.CompleteK32(m)), float64(float32(mathext.CompleteK(float64(m))))); e > tol {
+					t.Fatalf("\t%s\tTest 0:\tShould get K within %g at m=%v, got rel err %g.", failed, tol, m, e)
+				}
+				if e := relErr(float64(mathext.CompleteE32(m)), float64(float32(mathext.CompleteE(float64(m))))); e > tol {
+					t.Fatalf("\t%s\tTest 0:\tShould get E within %g at m=%v, got rel err %g.", failed, tol, m, e)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould agree within %g.", succeed, tol)
+		}
+
+		t.Logf("\tTest 1:\tWhen approaching m = 1.")
+		{
+			for m := float32(0.5); m < 1; m = 1 - (1-m)/2 {
+				if e := relErr(float64(mathext.CompleteK32(m)), float64(float32(mathext.CompleteK(float64(m))))); e > tol {
+					t.Fatalf("\t%s\tTest 1:\tShould get K within %g at m=%v, got rel err %g.", failed, tol, m, e)
+				}
+				if e := relErr(float64(mathext.CompleteE32(m)), float64(float32(mathext.CompleteE(float64(m))))); e > tol {
+					t.Fatalf("\t%s\tTest 1:\tShould get E within %g at m=%v, got rel err %g.", failed, tol, m, e)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould agree within %g.", succeed, tol)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking the ends of the domain.")
+		{
+			if k, e := mathext.CompleteK32(1), mathext.CompleteE32(1); !math.IsInf(float64(k), 1) || e != 1 {
+				t.Fatalf("\t%s\tTest 2:\tShould get +Inf and 1 at m = 1, got %v and %v.", failed, k, e)
+			}
+			for _, m := range []float32{-0.5, 1.5, float32(math.NaN())} {
+				if k, e := mathext.CompleteK32(m), mathext.CompleteE32(m); !math.IsNaN(float64(k)) || !math.IsNaN(float64(e)) {
+					t.Fatalf("\t%s\tTest 2:\tShould get NaN for m=%v, got %v and %v.", failed, m, k, e)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould handle the ends of the domain.", succeed)
+		}
+	}
+}
+
+var k32 float32
+
+func BenchmarkCompleteK32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		k32 = mathext.CompleteK32(0.7)
+	}
+}
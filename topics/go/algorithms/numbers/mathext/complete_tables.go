@@ -0,0 +1,499 @@
+package mathext
+
+// taylorBranch is a Taylor expansion around center that is used for
+// parameters below upper.
+type taylorBranch struct {
+	upper  float64
+	center float64
+	coeffs []float64
+}
+
+// kTaylor holds the expansions of K(m) over [0, 0.9). The coefficients
+// follow from the hypergeometric differential equation of K and are
+// truncated once the remainder drops below 2⁻⁵⁷ on the interval.
+var kTaylor = [...]taylorBranch{
+	{
+		upper:  0.1,
+		center: 0.05,
+		coeffs: []float64{
+			1.5910034537907922,
+			0.41600074399178694,
+			0.24579151426410342,
+			0.17948148291490615,
+			0.14455605708755515,
+			0.12320099331242772,
+			0.10893881157429353,
+			0.09885340987159291,
+			0.09143962920174975,
+			0.0858425915954139,
+			0.08154111871830322,
+			0.07819965681125648,
+			0.07559261753542242,
+		},
+	},
+	{
+		upper:  0.2,
+		center: 0.15,
+		coeffs: []float64{
+			1.63525673226458,
+			0.4711906261487323,
+			0.3097284108314996,
+			0.2522083117731357,
+			0.22672562321968465,
+			0.21577444672958598,
+			0.21310877187734892,
+			0.21602912460518828,
+			0.2232558316330579,
+			0.23418050129420992,
+			0.24855768297226408,
+			0.26636380989261754,
+			0.28772845215611464,
+		},
+	},
+	{
+		upper:  0.3,
+		center: 0.25,
+		coeffs: []float64{
+			1.685750354812596,
+			0.5417318486132803,
+			0.40152443839069024,
+			0.3696424734208891,
+			0.37606071535458363,
+			0.4052358870851259,
+			0.45329438175399905,
+			0.5205189476511842,
+			0.609426039204995,
+			0.7242635222829089,
+			0.8710138477098124,
+			1.057652872753547,
+			1.2945970872087764,
+			1.5953368253888784,
+		},
+	},
+	{
+		upper:  0.4,
+		center: 0.35,
+		coeffs: []float64{
+			1.7443505972256133,
+			0.6348642753719353,
+			0.5398425641644455,
+			0.5718927051937874,
+			0.6702951362654062,
+			0.8325865900109772,
+			1.0738574482479333,
+			1.4220914606754977,
+			1.9203871834023047,
+			2.6325525483316543,
+			3.6521097473190394,
+			5.115867135558866,
+			7.224080007363877,
+			10.270306349944788,
+		},
+	},
+	{
+		upper:  0.5,
+		center: 0.45,
+		coeffs: []float64{
+			1.8138839368169826,
+			0.7631632457005573,
+			0.7619286053215958,
+			0.9510746536684279,
+			1.315180671703161,
+			1.9285606934774109,
+			2.9375093425313787,
+			4.594894405442878,
+			7.33007122188172,
+			11.871512597425301,
+			19.45851374822938,
+			32.20638657246427,
+			53.73749198700555,
+			90.27388602941,
+			152.53312130253275,
+		},
+	},
+	{
+		upper:  0.6,
+		center: 0.55,
+		coeffs: []float64{
+			1.8989249102715535,
+			0.9505217946182445,
+			1.1510775899590158,
+			1.7502391069863006,
+			2.952676812636875,
+			5.285800396121451,
+			9.83248571665998,
+			18.787148683275596,
+			36.61468615273698,
+			72.45292395127771,
+			145.1079577347069,
+			293.4786396308497,
+			598.385181505501,
+			1228.4200130758634,
+			2536.5297553827645,
+			5263.983272507519,
+			10972.138126273492,
+		},
+	},
+	{
+		upper:  0.7,
+		center: 0.65,
+		coeffs: []float64{
+			2.0075983984243764,
+			1.2484572312123474,
+			1.9262346570764797,
+			3.7512896400875877,
+			8.119944554932045,
+			18.665721308735552,
+			44.603924842914374,
+			109.50920543094983,
+			274.2779548232414,
+			697.5598008606327,
+			1795.7160145002472,
+			4668.38171679039,
+			12235.762468136643,
+			32290.17809718321,
+			85713.07608195965,
+			228672.1890493117,
+			612757.2711915852,
+			1648323.3976504668,
+			4449225.104621196,
+		},
+	},
+	{
+		upper:  0.8,
+		center: 0.75,
+		coeffs: []float64{
+			2.1565156474996434,
+			1.7918056418494632,
+			3.8267512874657132,
+			10.386724683637972,
+			31.403314054680703,
+			100.92370394986955,
+			337.3268282632273,
+			1158.7079305678278,
+			4060.9907421936323,
+			14454.001840343448,
+			52076.661075994045,
+			189493.65914621568,
+			695184.5762413896,
+			2567994.048255285,
+			9541921.966748387,
+			35634927.44218076,
+			133669298.46120408,
+			503352186.68662846,
+			1901975729.53866,
+			7208915015.330104,
+			27398741806.33951,
+			104392867248.85301,
+		},
+	},
+	{
+		upper:  0.85,
+		center: 0.825,
+		coeffs: []float64{
+			2.3181226217125106,
+			2.6169201502912327,
+			7.897935075731356,
+			30.502397154466724,
+			131.48693655235286,
+			602.9847637356492,
+			2877.024617809973,
+			14110.519919151804,
+			70621.4408815654,
+			358977.266582531,
+			1847238.2637239718,
+			9600515.416049214,
+			50307677.08502367,
+			265444188.6527128,
+			1408862325.0287027,
+			7515687935.373775,
+			40270783964.955246,
+			216620893258.01126,
+			1169248920192.9995,
+		},
+	},
+	{
+		upper:  0.9,
+		center: 0.875,
+		coeffs: []float64{
+			2.473596173751344,
+			3.727624244118099,
+			15.607393035549306,
+			84.12850842805888,
+			506.98181970406137,
+			3252.2770581451236,
+			21713.242419574344,
+			149037.04518909327,
+			1043999.3310899908,
+			7427974.817042039,
+			53503839.67558661,
+			389249886.99487084,
+			2855288351.1008105,
+			21090077038.76684,
+			156699833947.7902,
+			1170222242422.44,
+			8777948323668.9375,
+			66101242752484.95,
+			499488053713388.8,
+			3785974339724030.0,
+			2.877599612303611e+16,
+			2.192634683992576e+17,
+		},
+	},
+}
+
+// eTaylor holds the expansions of E(m) over [0, 0.9), built the same way
+// as kTaylor.
+var eTaylor = [...]taylorBranch{
+	{
+		upper:  0.1,
+		center: 0.05,
+		coeffs: []float64{
+			1.5509733517804722,
+			-0.4003010201031985,
+			-0.07849861944294194,
+			-0.034318853117591995,
+			-0.0197180433173655,
+			-0.01305950773199331,
+			-0.009442372874146548,
+			-0.007246728512402157,
+			-0.00580742401295609,
+			-0.004809187786009338,
+			-0.0040863992332551505,
+			-0.0035450302604139563,
+		},
+	},
+	{
+		upper:  0.2,
+		center: 0.15,
+		coeffs: []float64{
+			1.5101218320928198,
+			-0.41711633390586755,
+			-0.09012382040477457,
+			-0.04372994401908431,
+			-0.027965493064761784,
+			-0.020644781177568107,
+			-0.016650786739707237,
+			-0.01426196082884252,
+			-0.012759847429264802,
+			-0.011799303775587354,
+			-0.011197445703074968,
+			-0.010850368064799902,
+		},
+	},
+	{
+		upper:  0.3,
+		center: 0.25,
+		coeffs: []float64{
+			1.4674622093394272,
+			-0.43657629094633776,
+			-0.10515555766694255,
+			-0.05737184359324173,
+			-0.04139162772734022,
+			-0.03452772850528084,
+			-0.031495443512532785,
+			-0.030527000890325277,
+			-0.0309169840192389,
+			-0.03237139531475812,
+			-0.03478996038640416,
+			-0.03818265461238788,
+			-0.04263618764890025,
+		},
+	},
+	{
+		upper:  0.4,
+		center: 0.35,
+		coeffs: []float64{
+			1.4226911334908792,
+			-0.4595135196210487,
+			-0.12525053982206188,
+			-0.07813854509440948,
+			-0.06471427847205,
+			-0.06208433913173031,
+			-0.06519703281557247,
+			-0.07279389536257878,
+			-0.084959075171781,
+			-0.102539850131046,
+			-0.12705358515769605,
+			-0.1607911206912746,
+			-0.20705400012405942,
+		},
+	},
+	{
+		upper:  0.5,
+		center: 0.45,
+		coeffs: []float64{
+			1.3754019718711163,
+			-0.4872021832731848,
+			-0.15331170134854022,
+			-0.11184944491702783,
+			-0.10884095252313576,
+			-0.12295422312026907,
+			-0.15221716396203505,
+			-0.20049532364269734,
+			-0.27617433306775174,
+			-0.39351311430437586,
+			-0.5757544060278792,
+			-0.8605232357272398,
+			-1.3088332057585401,
+			-2.0200280559452244,
+		},
+	},
+	{
+		upper:  0.6,
+		center: 0.55,
+		coeffs: []float64{
+			1.3250244979582302,
+			-0.5217276475575667,
+			-0.19490643048212622,
+			-0.17162372682201127,
+			-0.20275465292641914,
+			-0.27879895311853475,
+			-0.42069845728100574,
+			-0.675948400853106,
+			-1.1363431218392293,
+			-1.9767211439543984,
+			-3.5316967730957227,
+			-6.446753640156048,
+			-11.97703130208884,
+			-22.581360948073964,
+			-43.10947982948145,
+		},
+	},
+	{
+		upper:  0.7,
+		center: 0.65,
+		coeffs: []float64{
+			1.2707074796501499,
+			-0.5668391682878666,
+			-0.2621607934324926,
+			-0.2922441735330774,
+			-0.4403978408504232,
+			-0.7749476413813975,
+			-1.498870837987561,
+			-3.089708310445187,
+			-6.6675959033810015,
+			-14.89436036517319,
+			-34.18120574251449,
+			-80.15895841905397,
+			-191.34894807629848,
+			-463.5938853480342,
+			-1137.38082216936,
+			-2820.707378635227,
+			-7061.138224465872,
+		},
+	},
+	{
+		upper:  0.8,
+		center: 0.75,
+		coeffs: []float64{
+			1.2110560275684594,
+			-0.6303064132874558,
+			-0.38716640952066916,
+			-0.5922782353119346,
+			-1.23755558451305,
+			-3.0320566617452474,
+			-8.18168822157359,
+			-23.55507217389693,
+			-71.04099935893065,
+			-221.879685319235,
+			-712.1364793277636,
+			-2336.1253314403966,
+			-7801.945954775964,
+			-26448.19586059192,
+			-90799.48341621365,
+			-315126.04064491636,
+			-1104011.3443115912,
+			-3899801.8348056767,
+			-13876249.116223745,
+			-49694982.823537864,
+		},
+	},
+	{
+		upper:  0.85,
+		center: 0.825,
+		coeffs: []float64{
+			1.1613071521962828,
+			-0.7011002845552895,
+			-0.5805514744654373,
+			-1.2436930610777865,
+			-3.679383613496635,
+			-12.815909243378957,
+			-49.25672530759985,
+			-202.18187354340904,
+			-869.8602699308701,
+			-3877.0058473132895,
+			-17761.7071017094,
+			-83182.69029154233,
+			-396650.4505013548,
+			-1920033.4136826345,
+			-9413132.177950084,
+			-46654858.83733537,
+		},
+	},
+	{
+		upper:  0.9,
+		center: 0.875,
+		coeffs: []float64{
+			1.1246173251197522,
+			-0.7708450563609095,
+			-0.8447940536449113,
+			-2.4900973094503946,
+			-10.239717411543843,
+			-49.7490054655148,
+			-267.09866751957054,
+			-1532.66588382523,
+			-9222.313478526092,
+			-57502.51612140314,
+			-368596.11674161063,
+			-2415611.0887010912,
+			-16120097.815816568,
+			-109209938.52030899,
+			-749380758.1942496,
+			-5198725846.725541,
+			-36409256888.1214,
+			-257118028912.17395,
+			-1829090406297.8796,
+		},
+	},
+}
+
+// eLogL and eLogC hold the expansion of E(m) around m = 1 used for
+// m >= 0.9, where E(m) = ln(16/mc)·Σ eLogL[k]·mcᵏ + Σ eLogC[k]·mcᵏ.
+var eLogL = [...]float64{
+	0,
+	0.25,
+	0.09375,
+	0.05859375,
+	0.042724609375,
+	0.0336456298828125,
+	0.027757644653320312,
+	0.023627042770385742,
+	0.020568184554576874,
+	0.018211413407698274,
+	0.016339684807462618,
+	0.01481712326858542,
+	0.013554300262740071,
+	0.012489940145954392,
+	0.01158064505291101,
+	0.010794815567177762,
+}
+
+var eLogC = [...]float64{
+	1.0,
+	-0.25,
+	-0.203125,
+	-0.140625,
+	-0.10691324869791667,
+	-0.08614349365234375,
+	-0.07210578918457031,
+	-0.06199338436126709,
+	-0.05436488067997353,
+	-0.048406362059592666,
+	-0.04362405740343208,
+	-0.03970121666819371,
+	-0.036425376655683704,
+	-0.033648734595586115,
+	-0.03126530214489766,
+	-0.02919710145189081,
+}
@@ -0,0 +1,1301 @@
+package mathext_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestCompletePiAbramowitz(t *testing.T) {
+	// Entries of Abramowitz and Stegun Table 17.9 at φ = 90°, given there
+	// to five decimals, with m = sin²α.
+	tt := []struct {
+		name  string
+		n     float64
+		alpha float64
+		want  float64
+	}{
+		{"n0.1a15", 0.1, 15, 1.68536},
+		{"n0.1a75", 0.1, 75, 2.96601},
+		{"n0.3a30", 0.3, 30, 2.02779},
+		{"n0.5a45", 0.5, 45, 2.70129},
+		{"n0.5a60", 0.5, 60, 3.23477},
+		{"n0.7a45", 0.7, 45, 3.56211},
+		{"n0.9a15", 0.9, 15, 5.09958},
+		{"n0.9a75", 0.9, 75, 12.46409},
+	}
+
+	t.Log("Given the need to pin the Abramowitz and Stegun convention for Π(n|m).")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				s := math.Sin(test.alpha * math.Pi / 180)
+				m := s * s
+
+				t.Logf("\tTest %d:\tWhen checking Π(%v|sin²%v°).", testID, test.n, test.alpha)
+				{
+					got := mathext.CompletePiAbramowitz(test.n, m)
+					if math.Abs(got-test.want) > 5e-6 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v to five decimals, got %v.", failed, testID, test.want, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v to five decimals.", succeed, testID, test.want)
+
+					if got != mathext.CompletePi(test.n, m) {
+						t.Fatalf("\t%s\tTest %d:\tShould match CompletePi.", failed, testID)
+					}
+					t.Logf("\t%s\tTest %d:\tShould match CompletePi.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCompletePiConvention(t *testing.T) {
+	t.Log("Given the need to detect a flipped sign on the characteristic.")
+	{
+		t.Logf("\tTest 0:\tWhen checking Π(n|0) = π/(2√(1-n)).")
+		{
+			for _, n := range []float64{-3, -0.5, 0.25, 0.75} {
+				got := mathext.CompletePiAbramowitz(n, 0)
+				want := math.Pi / (2 * math.Sqrt(1-n))
+				if e := relErr(got, want); e > 1e-15 {
+					t.Fatalf("\t%s\tTest 0:\tShould get %v for n=%v, got %v.", failed, want, n, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match the closed form.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking Π(√m|m) - Π(-√m|m) = π√m/(2(1-m)).")
+		{
+			for _, m := range []float64{0.04, 0.36, 0.81} {
+				k := math.Sqrt(m)
+				got := mathext.CompletePiAbramowitz(k, m) - mathext.CompletePiAbramowitz(-k, m)
+				want := math.Pi * k / (2 * (1 - m))
+				if e := relErr(got, want); e > 1e-14 {
+					t.Fatalf("\t%s\tTest 1:\tShould get %v for m=%v, got %v.", failed, want, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould match the closed form.", succeed)
+		}
+	}
+}
+
+func TestCompletePiSweep(t *testing.T) {
+
+	// 60 digit values across the characteristic. Before the changes of
+	// characteristic, n < -100 and n > 1 lost up to nine digits to
+	// cancellation against K(m).
+	refs := []struct {
+		n, m, want float64
+	}{
+		{-1e8, 0.1, 0.0001570804486526693},
+		{-1e4, 0.1, 0.015715267703034216},
+		{-100, 0.1, 0.15703820716349703},
+		{-1, 0.1, 1.1350336427288368},
+		{-0.001, 0.1, 1.6116251264228962},
+		{0.0999, 0.1, 1.7007460917305228},
+		{0.1, 0.1, 1.7008418187752925},
+		{0.1001, 0.1, 1.7009375618445524},
+		{0.999, 0.1, 52.274293287654025},
+		{1.001, 0.1, -0.08830483863372207},
+		{2, 0.1, -0.04244737893462834},
+		{10, 0.1, -0.008230423082901698},
+		{100, 0.1, -0.0008174529669662553},
+		{1e4, 0.1, -8.16843271619667e-06},
+		{1e8, 0.1, -8.168371188398964e-10},
+		{-1e8, 0.5, 0.00015708466580933552},
+		{-1e4, 0.5, 0.01575712639948631},
+		{-100, 0.5, 0.16092573342261243},
+		{-1, 0.5, 1.2731273667496825},
+		{-0.001, 0.5, 1.8530685927246635},
+		{0.4995, 0.5, 2.6998236495219285},
+		{0.5, 0.5, 2.701287762095351},
+		{0.5005, 0.5, 2.702754127375934},
+		{0.999, 0.5, 69.43465204211546},
+		{1.001, 0.5, -0.8457504337482691},
+		{2, 0.5, -0.31354468346518405},
+		{10, 0.5, -0.05237334291610625},
+		{100, 0.5, -0.005053832435417473},
+		{1e4, 0.5, -5.0345023891146426e-05},
+		{1e8, 0.5, -5.0343079819787995e-09},
+		{-1e8, 0.9, 0.00015709436436093033},
+		{-1e4, 0.9, 0.015853791964571658},
+		{-100, 0.9, 0.1702261930661335},
+		{-1, 0.9, 1.6647669360483535},
+		{-0.001, 0.9, 2.576456441954457},
+		{0.8991, 0.9, 10.986805806595589},
+		{0.9, 0.9, 11.047747327040735},
+		{0.9008999999999999, 0.9, 11.109563378824314},
+		{0.999, 0.9, 149.2604820324057},
+		{1.001, 0.9, -8.408774144145152},
+		{2, 0.9, -1.1940862272006614},
+		{10, 0.9, -0.15920202073190723},
+		{100, 0.9, -0.014843323798669105},
+		{1e4, 0.9, -0.00014734266667372115},
+		{1e8, 0.9, -1.473317391571844e-08},
+		{-1e8, 0.99, 0.00015710642755449345},
+		{-1e4, 0.99, 0.01597434151068761},
+		{-100, 0.99, 0.18209709012230832},
+		{-1, 0.99, 2.2374901533106617},
+		{-0.001, 0.99, 3.6929330327258714},
+		{0.98901, 0.99, 95.46567993811937},
+		{0.99, 0.99, 101.5993545025223},
+		{0.9909899999999999, 0.99, 108.7876475987864},
+		{0.999, 0.99, 418.23707823014075},
+		{1.001, 0.99, -91.77572593623078},
+		{2, 0.99, -2.4236175036401773},
+		{10, 0.99, -0.2936630743416206},
+		{100, 0.99, -0.02703212780329652},
+		{1e4, 0.99, -0.00026798773826098524},
+		{1e8, 0.99, -2.6796438413189886e-08},
+	}
+
+	t.Log("Given the need to evaluate the integral of the third kind for any characteristic.")
+	{
+		t.Logf("\tTest 0:\tWhen sweeping n from -1e8 to 1e8 at m in [0.1, 0.99].")
+		{
+			for _, r := range refs {
+				if got := mathext.CompletePi(r.n, r.m); math.Abs(got-r.want) > 8*ulp(r.want) {
+					t.Fatalf("\t%s\tTest 0:\tShould get Π(%v|%v) = %v, got %v.", failed, r.n, r.m, r.want, got)
+				}
+			}
+			if got := mathext.CompletePi(math.Inf(-1), 0.5); got != 0 {
+				t.Fatalf("\t%s\tTest 0:\tShould get 0 at n = -Inf, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get the reference values to eight ulp.", succeed)
+		}
+
+		// (1 - n)(m - n) overflows once |n| passes 1e154, which used to
+		// turn these into 0 and +Inf into NaN.
+		huge := []struct {
+			n, m, want float64
+		}{
+			{-1e160, 0.5, 1.5707963267948966e-80},
+			{-1e300, 0.9, 1.5707963267948966e-150},
+			{-math.MaxFloat64, 0.5, 1.171553422455405e-154},
+		}
+
+		t.Logf("\tTest 1:\tWhen n is beyond the square root of the largest float64.")
+		{
+			for _, r := range huge {
+				if got := mathext.CompletePi(r.n, r.m); math.Abs(got-r.want) > 8*ulp(r.want) {
+					t.Fatalf("\t%s\tTest 1:\tShould get Π(%v|%v) = %v, got %v.", failed, r.n, r.m, r.want, got)
+				}
+			}
+			if got := mathext.CompletePi(math.Inf(1), 0.5); got != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 0 at n = +Inf, got %v.", failed, got)
+			}
+			if got := mathext.EllipticPi(math.Inf(1), 4, 0.5); got != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 0 past a half period at n = +Inf, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get the reference values to eight ulp.", succeed)
+		}
+	}
+}
+
+func TestCompletePiNearPole(t *testing.T) {
+	tt := []struct {
+		name string
+		n, m float64
+		want float64
+	}{
+		{"2^-20", 1 - 0x1p-20, 0.3, 1922.1692437580518},
+		{"2^-30", 1 - 0x1p-30, 0.3, 61520.28161715504},
+		{"2^-40", 1 - 0x1p-40, 0.3, 1968659.889719291},
+		{"2^-52", 1 - 0x1p-52, 0.3, 125994255.04965064},
+	}
+
+	t.Log("Given the need to evaluate the complete integral of the third kind close to its pole.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking Π(%v|%v).", testID, test.n, test.m)
+				{
+					got := mathext.CompletePi(test.n, test.m)
+					if e := relErr(got, test.want); e > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCompleteK(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		want float64
+	}{
+		{"zero", 0, 1.5707963267948966},
+		{"small", 0.01, 1.574745561517356},
+		{"tenth", 0.1, 1.6124413487202194},
+		{"quarter", 0.25, 1.685750354812596},
+		{"half", 0.5, 1.8540746773013719},
+		{"threeQuarters", 0.75, 2.1565156474996434},
+		{"edge", 0.85, 2.38901648632558},
+		{"logEdge", 0.9, 2.5780921133481733},
+		{"near", 0.99, 3.695637362989874},
+		{"nearer", 0.999999, 8.294051463601063},
+		{"nearest", 1 - 0x1p-40, 15.249237972322037},
+	}
+
+	t.Log("Given the need to evaluate the complete integral of the first kind.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking K(%v).", testID, test.m)
+				{
+					got := mathext.CompleteK(test.m)
+					if e := relErr(got, test.want); e > 4e-16 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCompleteKc(t *testing.T) {
+	t.Log("Given the need to evaluate K from the complementary parameter.")
+	{
+		t.Logf("\tTest 0:\tWhen the complement is exact.")
+		{
+			for _, mc := range []float64{0, 0x1p-40, 0x1p-20, 0.01171875, 0.125, 0.5, 0.875, 1} {
+				if got, want := mathext.CompleteKc(mc), mathext.CompleteK(1-mc); got != want {
+					t.Fatalf("\t%s\tTest 0:\tShould match CompleteK(1-mc) at mc=%v: got %v, want %v.", failed, mc, got, want)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match CompleteK(1-mc).", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen mc is tiny.")
+		{
+			tt := []struct {
+				mc, want float64
+			}{
+				{3e-10, 12.349913682607308},
+				{1e-18, 22.1095601980663},
+				{1e-30, 35.92507075603058},
+				{1e-300, 346.77405831022674},
+			}
+			for _, test := range tt {
+				if got := mathext.CompleteKc(test.mc); relErr(got, test.want) > 4e-16 {
+					t.Fatalf("\t%s\tTest 1:\tShould get %v at mc=%v, got %v.", failed, test.want, test.mc, got)
+				}
+			}
+			if k := mathext.CompleteK(1 - 1e-18); !math.IsInf(k, 1) {
+				t.Fatalf("\t%s\tTest 1:\tShould lose mc=1e-18 in CompleteK(1-mc), got %v.", failed, k)
+			}
+			t.Logf("\t%s\tTest 1:\tShould keep the digits that 1-mc loses.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking arguments outside of the domain.")
+		{
+			if !math.IsNaN(mathext.CompleteKc(-0.1)) || !math.IsNaN(mathext.CompleteKc(1.5)) || !math.IsNaN(mathext.CompleteKc(math.NaN())) {
+				t.Fatalf("\t%s\tTest 2:\tShould get NaN.", failed)
+			}
+			t.Logf("\t%s\tTest 2:\tShould get NaN.", succeed)
+		}
+	}
+}
+
+func TestCompleteEc(t *testing.T) {
+	t.Log("Given the need to evaluate E from the complementary parameter.")
+	{
+		t.Logf("\tTest 0:\tWhen the complement is exact.")
+		{
+			for _, mc := range []float64{0, 0x1p-40, 0x1p-20, 0.01171875, 0.125, 0.5, 0.875, 1} {
+				if got, want := mathext.CompleteEc(mc), mathext.CompleteE(1-mc); got != want {
+					t.Fatalf("\t%s\tTest 0:\tShould match CompleteE(1-mc) at mc=%v: got %v, want %v.", failed, mc, got, want)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match CompleteE(1-mc).", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen mc is small.")
+		{
+			tt := []struct {
+				mc, want float64
+			}{
+				{3e-10, 1.000000001777487},
+				{1e-7, 1.0000004472671251},
+				{1e-4, 1.000274582430663},
+				{0.3, 1.2416705679458226},
+			}
+			for _, test := range tt {
+				if got := mathext.CompleteEc(test.mc); math.Abs(got-test.want) > 2*ulp(test.want) {
+					t.Fatalf("\t%s\tTest 1:\tShould get %v at mc=%v, got %v.", failed, test.want, test.mc, got)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get the reference values to two ulp.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking arguments outside of the domain.")
+		{
+			if !math.IsNaN(mathext.CompleteEc(-0.1)) || !math.IsNaN(mathext.CompleteEc(1.5)) || !math.IsNaN(mathext.CompleteEc(math.NaN())) {
+				t.Fatalf("\t%s\tTest 2:\tShould get NaN.", failed)
+			}
+			t.Logf("\t%s\tTest 2:\tShould get NaN.", succeed)
+		}
+	}
+}
+
+func TestCompleteKHalf(t *testing.T) {
+	t.Log("Given the need for K at the symmetric point m = 1/2.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with the closed form and a 30 digit value.")
+		{
+			got := mathext.CompleteKHalf()
+
+			g := math.Gamma(0.25)
+			if closed := g * g / (4 * math.Sqrt(math.Pi)); math.Abs(got-closed) > 4*ulp(closed) {
+				t.Fatalf("\t%s\tTest 0:\tShould match Γ(1/4)²/(4√π) = %v, got %v.", failed, closed, got)
+			}
+			if want := 1.854074677301371918433850347195; got != want {
+				t.Fatalf("\t%s\tTest 0:\tShould get %v, got %v.", failed, want, got)
+			}
+			if k := mathext.CompleteK(0.5); got != k {
+				t.Fatalf("\t%s\tTest 0:\tShould match CompleteK(0.5) = %v, got %v.", failed, k, got)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get K(1/2) rounded to nearest.", succeed)
+		}
+	}
+}
+
+func TestCompleteAll(t *testing.T) {
+	t.Log("Given the need for K, E, K' and E' at one parameter.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with the separate calls and Legendre's relation.")
+		{
+			// The complements of these m are exact, so the separate calls
+			// see the same arguments.
+			for _, m := range []float64{0x1p-30, 0x1p-10, 0.125, 0.3125, 0.5, 0.75, 0.9375, 1 - 0x1p-10, 1 - 0x1p-30} {
+				k, e, kc, ec := mathext.CompleteAll(m)
+				if k != mathext.CompleteK(m) || e != mathext.CompleteE(m) || kc != mathext.CompleteK(1-m) || ec != mathext.CompleteE(1-m) {
+					t.Fatalf("\t%s\tTest 0:\tShould match the separate calls at m=%v, got %v %v %v %v.", failed, m, k, e, kc, ec)
+				}
+			}
+			for _, m := range []float64{1e-9, 1e-3, 0.1, 0.3, 0.5, 0.75, 0.9, 0.99, 1 - 1e-9} {
+				k, e, kc, ec := mathext.CompleteAll(m)
+				if kc != mathext.CompleteKc(m) {
+					t.Fatalf("\t%s\tTest 0:\tShould match CompleteKc at m=%v, got %v.", failed, m, kc)
+				}
+
+				// The products grow like ln(1/m) at the ends and cancel, so
+				// the residual is measured in ulp of the largest one.
+				if r := e*kc + k*(ec-kc) - math.Pi/2; math.Abs(r) > 8*ulp(math.Max(k*kc, math.Pi/2)) {
+					t.Fatalf("\t%s\tTest 0:\tShould satisfy Legendre's relation at m=%v, got a residual of %g.", failed, m, r)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match and satisfy EK' + E'K - KK' = π/2.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the ends of the domain.")
+		{
+			if k, e, kc, ec := mathext.CompleteAll(0); k != math.Pi/2 || e != math.Pi/2 || !math.IsInf(kc, 1) || ec != 1 {
+				t.Fatalf("\t%s\tTest 1:\tShould get π/2, π/2, +Inf and 1 at m=0, got %v %v %v %v.", failed, k, e, kc, ec)
+			}
+			if k, e, kc, ec := mathext.CompleteAll(1); !math.IsInf(k, 1) || e != 1 || kc != math.Pi/2 || ec != math.Pi/2 {
+				t.Fatalf("\t%s\tTest 1:\tShould get +Inf, 1, π/2 and π/2 at m=1, got %v %v %v %v.", failed, k, e, kc, ec)
+			}
+			if k, _, _, _ := mathext.CompleteAll(1.5); !math.IsNaN(k) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN at m=1.5, got %v.", failed, k)
+			}
+			t.Logf("\t%s\tTest 1:\tShould handle the ends.", succeed)
+		}
+	}
+}
+
+func BenchmarkCompleteAll(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		k, _, _, _ = mathext.CompleteAll(0.3)
+	}
+}
+
+func BenchmarkCompleteFourCalls(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		k = mathext.CompleteK(0.3) + mathext.CompleteE(0.3) + mathext.CompleteK(0.7) + mathext.CompleteE(0.7)
+	}
+}
+
+func TestCompleteKReciprocal(t *testing.T) {
+	t.Log("Given the need to continue K(m) past m = 1.")
+	{
+		t.Logf("\tTest 0:\tWhen checking m in [0, 1].")
+		{
+			for _, m := range []float64{0, 0.3, 0.9} {
+				re, im := mathext.CompleteKReciprocal(m)
+				if re != mathext.CompleteK(m) || im != 0 {
+					t.Fatalf("\t%s\tTest 0:\tShould get (CompleteK(%v), 0), got (%v, %v).", failed, m, re, im)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get (CompleteK(m), 0).", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the reference value at m = 2.")
+		{
+			const want = 1.3110287771460598
+			re, im := mathext.CompleteKReciprocal(2)
+			if relErr(re, want) > 1e-15 || relErr(im, -want) > 1e-15 {
+				t.Fatalf("\t%s\tTest 1:\tShould get (%v, %v), got (%v, %v).", failed, want, -want, re, im)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get (%v, %v).", succeed, want, -want)
+		}
+
+		t.Logf("\tTest 2:\tWhen crossing m = 1.")
+		{
+			for _, d := range []float64{0x1p-20, 0x1p-30, 0x1p-40} {
+				below := mathext.CompleteK(1 - d)
+				re, im := mathext.CompleteKReciprocal(1 + d)
+				if e := relErr(re, below); e > 10*d {
+					t.Fatalf("\t%s\tTest 2:\tShould get a real part close to %v at 1+%g, got %v.", failed, below, d, re)
+				}
+				if e := relErr(im, -math.Pi/2); e > 10*d {
+					t.Fatalf("\t%s\tTest 2:\tShould get an imaginary part close to -π/2 at 1+%g, got %v.", failed, d, im)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould keep the real part continuous.", succeed)
+		}
+	}
+}
+
+func TestCompleteE(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		want float64
+	}{
+		{"zero", 0, 1.5707963267948966},
+		{"small", 0.01, 1.5668619420216683},
+		{"half", 0.5, 1.3506438810476755},
+		{"edge", 0.85, 1.1433957918831659},
+		{"logEdge", 0.9, 1.1047747327040733},
+		{"near", 0.99, 1.015993545025224},
+		{"one", 1, 1},
+	}
+
+	t.Log("Given the need to evaluate the complete integral of the second kind.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking E(%v).", testID, test.m)
+				{
+					got := mathext.CompleteE(test.m)
+					if e := relErr(got, test.want); e > 4e-16 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCompleteSmallM(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		k, e float64
+	}{
+		{"milli", 1e-3, 1.5711892469233444, 1.5704035540514236},
+		{"belowThreshold", 5e-7, 1.5707965231444927, 1.5707961304453373},
+		{"micro", 1e-6, 1.5707967194941992, 1.5707959340957414},
+		{"nano", 1e-9, 1.5707963271875958, 1.5707963264021976},
+		{"pico", 1e-12, 1.5707963267952894, 1.570796326794504},
+	}
+
+	t.Log("Given the need to evaluate K and E to within an ulp near m = 0.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking m=%v.", testID, test.m)
+				{
+					k := mathext.CompleteK(test.m)
+					if d := math.Abs(k - test.k); d > ulp(test.k) {
+						t.Fatalf("\t%s\tTest %d:\tShould get K=%v, got %v : off by %v ulp.", failed, testID, test.k, k, d/ulp(test.k))
+					}
+					t.Logf("\t%s\tTest %d:\tShould get K=%v.", succeed, testID, test.k)
+
+					e := mathext.CompleteE(test.m)
+					if d := math.Abs(e - test.e); d > ulp(test.e) {
+						t.Fatalf("\t%s\tTest %d:\tShould get E=%v, got %v : off by %v ulp.", failed, testID, test.e, e, d/ulp(test.e))
+					}
+					t.Logf("\t%s\tTest %d:\tShould get E=%v.", succeed, testID, test.e)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestMustComplete(t *testing.T) {
+	fns := []struct {
+		name  string
+		must  func(float64) float64
+		plain func(float64) float64
+	}{
+		{"K", mathext.MustCompleteK, mathext.CompleteK},
+		{"E", mathext.MustCompleteE, mathext.CompleteE},
+	}
+
+	t.Log("Given the need to fail loudly on a parameter outside of [0, 1].")
+	{
+		for testID, test := range fns {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking valid parameters.", testID)
+				{
+					for _, m := range []float64{0, 0.25, 0.9, 1} {
+						if got, want := test.must(m), test.plain(m); got != want {
+							t.Fatalf("\t%s\tTest %d:\tShould get %v for m=%v, got %v.", failed, testID, want, m, got)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould match the plain function.", succeed, testID)
+				}
+
+				t.Logf("\tTest %d:\tWhen checking invalid parameters.", testID)
+				{
+					for _, m := range []float64{-0.1, 1.5, math.Inf(1), math.NaN()} {
+						msg := mustPanic(func() { test.must(m) })
+						if msg != "mathext: m out of domain [0,1]" {
+							t.Fatalf("\t%s\tTest %d:\tShould panic with the documented message for m=%v, got %q.", failed, testID, m, msg)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould panic.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCompleteKClamped(t *testing.T) {
+	tt := []struct {
+		name   string
+		m, tol float64
+		want   float64
+	}{
+		{"noiseAboveOne", 1 + 1e-14, 1e-12, math.Inf(1)},
+		{"noiseBelowZero", -1e-15, 1e-12, math.Pi / 2},
+		{"inside", 0.5, 1e-12, 1.8540746773013719},
+		{"farOut", 1.5, 1e-12, math.NaN()},
+		{"strict", 1 + 1e-14, 0, math.NaN()},
+		{"negativeTol", 0.5, -1, math.NaN()},
+		{"nan", math.NaN(), 1, math.NaN()},
+	}
+
+	t.Log("Given the need to absorb rounding noise at the ends of [0, 1].")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking m=%v with tol=%v.", testID, test.m, test.tol)
+				{
+					got := mathext.CompleteKClamped(test.m, test.tol)
+					if got != test.want && !(math.IsNaN(got) && math.IsNaN(test.want)) {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v.", failed, testID, test.want, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+// mustPanic runs f and returns the value it panicked with.
+func mustPanic(f func()) (msg interface{}) {
+	defer func() {
+		msg = recover()
+	}()
+	f()
+
+	return nil
+}
+
+var k float64
+
+func BenchmarkCompleteK(b *testing.B) {
+	for _, m := range []float64{0.05, 0.45, 0.875, 0.95} {
+		b.Run(fmt.Sprint(m), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				k = mathext.CompleteK(m)
+			}
+		})
+	}
+}
+
+func TestCompleteExt(t *testing.T) {
+
+	// 60 digit values from the transformations applied to K and E inside
+	// [0, 1]. Past 1 they are the limits from below the cut.
+	refs := []struct {
+		m        float64
+		kRe, kIm float64
+		eRe, eIm float64
+	}{
+		{-1e6, 0.00829404781659062, 0, 1000.0043970243486, 0},
+		{-3, 1.0782578237498217, 0, 2.422112055136919, 0},
+		{-0.5, 1.4157372084259563, 0, 1.7517712756948178, 0},
+		{2, 1.3110287771460598, -1.3110287771460598, 0.5990701173677961, 0.5990701173677961},
+		{1 + 0x1p-30, 11.78350206700834, -1.5707963264291671, 0.9999999947457099, 7.314590393781207e-10},
+		{1e6, 0.0015707967194941992, -0.00829405146361544, 0.0007853982615722556, 999.9956029747084},
+	}
+
+	t.Log("Given the need to evaluate K and E for any real parameter.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with 60 digit values.")
+		{
+			for _, r := range refs {
+				kRe, kIm := mathext.CompleteKExt(r.m)
+				if math.Abs(kRe-r.kRe) > 4*ulp(r.kRe) || math.Abs(kIm-r.kIm) > 4*ulp(r.kIm) {
+					t.Fatalf("\t%s\tTest 0:\tShould get K(%v) = %v%+vi, got %v%+vi.", failed, r.m, r.kRe, r.kIm, kRe, kIm)
+				}
+				eRe, eIm := mathext.CompleteEExt(r.m)
+				if math.Abs(eRe-r.eRe) > 4*ulp(r.eRe) || math.Abs(eIm-r.eIm) > 4*ulp(r.eIm) {
+					t.Fatalf("\t%s\tTest 0:\tShould get E(%v) = %v%+vi, got %v%+vi.", failed, r.m, r.eRe, r.eIm, eRe, eIm)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get the reference values to four ulp.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen m is in [0, 1].")
+		{
+			for _, m := range []float64{0, 0.3, 0.999, 1} {
+				if re, im := mathext.CompleteKExt(m); re != mathext.CompleteK(m) || im != 0 {
+					t.Fatalf("\t%s\tTest 1:\tShould match CompleteK at m=%v, got %v%+vi.", failed, m, re, im)
+				}
+				if re, im := mathext.CompleteEExt(m); re != mathext.CompleteE(m) || im != 0 {
+					t.Fatalf("\t%s\tTest 1:\tShould match CompleteE at m=%v, got %v%+vi.", failed, m, re, im)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould match CompleteK and CompleteE.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen m is infinite or NaN.")
+		{
+			if re, im := mathext.CompleteKExt(math.Inf(-1)); re != 0 || im != 0 {
+				t.Fatalf("\t%s\tTest 2:\tShould get K(-Inf) = 0, got %v%+vi.", failed, re, im)
+			}
+			if re, im := mathext.CompleteKExt(math.Inf(1)); re != 0 || im != 0 {
+				t.Fatalf("\t%s\tTest 2:\tShould get K(+Inf) = 0, got %v%+vi.", failed, re, im)
+			}
+			if re, im := mathext.CompleteEExt(math.Inf(-1)); !math.IsInf(re, 1) || im != 0 {
+				t.Fatalf("\t%s\tTest 2:\tShould get E(-Inf) = +Inf, got %v%+vi.", failed, re, im)
+			}
+			if re, im := mathext.CompleteEExt(math.Inf(1)); re != 0 || !math.IsInf(im, 1) {
+				t.Fatalf("\t%s\tTest 2:\tShould get E(+Inf) = +Inf·i, got %v%+vi.", failed, re, im)
+			}
+			if re, im := mathext.CompleteEExt(math.NaN()); !math.IsNaN(re) || !math.IsNaN(im) {
+				t.Fatalf("\t%s\tTest 2:\tShould get NaN, got %v%+vi.", failed, re, im)
+			}
+			t.Logf("\t%s\tTest 2:\tShould get the limits.", succeed)
+		}
+	}
+}
+
+func TestCompleteKNegative(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		want float64
+	}{
+		{"lemniscatic", -1, 1.3110287771460598},
+		{"three", -3, 1.0782578237498217},
+		{"hundred", -100, 0.368219248609141},
+		{"huge", -1e10, 0.00012899219825792638},
+	}
+
+	t.Log("Given the need to evaluate K(m) for negative m.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking K(%v).", testID, test.m)
+				{
+					got := mathext.CompleteKNegative(test.m)
+					if e := relErr(got, test.want); e > 4e-16 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen approaching m = 0 from below.", len(tt))
+		{
+			if got := mathext.CompleteKNegative(0); got != math.Pi/2 {
+				t.Fatalf("\t%s\tTest %d:\tShould get π/2 at m=0, got %v.", failed, len(tt), got)
+			}
+			for _, m := range []float64{-1e-6, -1e-10} {
+				got, want := mathext.CompleteKNegative(m), math.Pi/2*(1+m/4)
+				if e := relErr(got, want); e > 1e-12 {
+					t.Fatalf("\t%s\tTest %d:\tShould get about %v at m=%v, got %v.", failed, len(tt), want, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould be continuous with CompleteK(0) = π/2.", succeed, len(tt))
+		}
+
+		t.Logf("\tTest %d:\tWhen checking the edges of the domain.", len(tt)+1)
+		{
+			if got := mathext.CompleteKNegative(math.Inf(-1)); got != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 0 at -Inf, got %v.", failed, len(tt)+1, got)
+			}
+			for _, m := range []float64{0.5, math.NaN()} {
+				if got := mathext.CompleteKNegative(m); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould get NaN for m=%v, got %v.", failed, len(tt)+1, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould handle -Inf and reject positive m.", succeed, len(tt)+1)
+		}
+	}
+}
+
+func TestCompleteKBranch(t *testing.T) {
+	t.Log("Given the need to correlate results with the branches of CompleteK.")
+	{
+		t.Logf("\tTest 0:\tWhen sweeping m over [0, 1].")
+		{
+			prev, steps := mathext.CompleteKBranch(0), 0
+			for i := 1; i <= 100000; i++ {
+				b := mathext.CompleteKBranch(float64(i) / 100000)
+				if b < prev {
+					t.Fatalf("\t%s\tTest 0:\tShould never decrease, got %d after %d at m=%v.", failed, b, prev, float64(i)/100000)
+				}
+				if b > prev {
+					steps++
+				}
+				prev = b
+			}
+			if prev != steps {
+				t.Fatalf("\t%s\tTest 0:\tShould step through every branch once, got %d steps to branch %d.", failed, steps, prev)
+			}
+			t.Logf("\t%s\tTest 0:\tShould be monotonic in m and so in 1-m.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen straddling the branch boundaries.")
+		{
+			for _, m := range []float64{1e-6, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.85, 0.9} {
+				below := mathext.CompleteKBranch(math.Nextafter(m, 0))
+				if at := mathext.CompleteKBranch(m); at != below+1 {
+					t.Fatalf("\t%s\tTest 1:\tShould step by one at m=%v, got %d and %d.", failed, m, below, at)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould differ by exactly one.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking m outside of [0, 1].")
+		{
+			for _, m := range []float64{-0.1, 1.1, math.NaN()} {
+				if got := mathext.CompleteKBranch(m); got != -1 {
+					t.Fatalf("\t%s\tTest 2:\tShould get -1 for m=%v, got %d.", failed, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get -1.", succeed)
+		}
+	}
+}
+
+func TestCompleteKDeterministic(t *testing.T) {
+	tt := []struct {
+		m    float64
+		bits uint64
+	}{
+		{5e-7, 0x3ff921fb88f937b8},
+		{0.05, 0x3ff974c0099dac08},
+		{0.35, 0x3ffbe8dc2bfd7926},
+		{0.65, 0x40000f8fbfc5e09d},
+		{0.875, 0x4003c9ecca6e2e9b},
+		{0.9, 0x40049feec2073f57},
+		{0.99, 0x400d90aa525f5667},
+		{0.999999, 0x4020968de9d703d6},
+	}
+
+	t.Log("Given the need to get identical bits on every platform.")
+	{
+		for testID, test := range tt {
+			t.Logf("\tTest %d:\tWhen checking K(%v).", testID, test.m)
+			{
+				got := mathext.CompleteKDeterministic(test.m)
+				if math.Float64bits(got) != test.bits {
+					t.Fatalf("\t%s\tTest %d:\tShould get bits %#016x, got %#016x.", failed, testID, test.bits, math.Float64bits(got))
+				}
+				t.Logf("\t%s\tTest %d:\tShould get bits %#016x.", succeed, testID, test.bits)
+
+				if d := math.Abs(got - mathext.CompleteK(test.m)); d > 2*ulp(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould stay within two ulp of CompleteK, off by %v ulp.", failed, testID, d/ulp(got))
+				}
+				t.Logf("\t%s\tTest %d:\tShould stay within two ulp of CompleteK.", succeed, testID)
+			}
+		}
+	}
+}
+
+func TestCompleteKAGM(t *testing.T) {
+	t.Log("Given the need to compute K(m) from the AGM with a bounded number of steps.")
+	{
+		t.Logf("\tTest 0:\tWhen allowing at least 6 steps.")
+		{
+			for _, maxIter := range []int{6, 10, 64} {
+				for m := 0.0; m <= 0.9999; m += 0.0101 {
+					k, iters := mathext.CompleteKAGM(m, maxIter)
+					if d := math.Abs(k - mathext.CompleteK(m)); d > 2*ulp(k) {
+						t.Fatalf("\t%s\tTest 0:\tShould get K(%v) with %d steps, off by %v ulp.", failed, m, maxIter, d/ulp(k))
+					}
+					if iters > maxIter {
+						t.Fatalf("\t%s\tTest 0:\tShould take at most %d steps at m=%v, took %d.", failed, maxIter, m, iters)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould stay within two ulp of CompleteK.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the reported number of steps.")
+		{
+			for _, m := range []float64{0.1, 0.5, 0.9, 0.999, 1 - 1e-12} {
+				k, iters := mathext.CompleteKAGM(m, 64)
+				if same, n := mathext.CompleteKAGM(m, iters); same != k || n != iters {
+					t.Fatalf("\t%s\tTest 1:\tShould get the same K(%v) with a cap of %d steps, got %v after %d.", failed, m, iters, same, n)
+				}
+				if _, n := mathext.CompleteKAGM(m, iters-1); n != iters-1 {
+					t.Fatalf("\t%s\tTest 1:\tShould use the whole cap of %d steps at m=%v, took %d.", failed, iters-1, m, n)
+				}
+			}
+			if k, iters := mathext.CompleteKAGM(0, 64); k != math.Pi/2 || iters != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get π/2 without any steps at m=0, got %v after %d.", failed, k, iters)
+			}
+			if k, iters := mathext.CompleteKAGM(0.5, 0); k != math.Pi/(1+math.Sqrt(0.5)) || iters != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get π/(1+√(1-m)) without any steps, got %v after %d.", failed, k, iters)
+			}
+			t.Logf("\t%s\tTest 1:\tShould report the steps that were taken.", succeed)
+		}
+	}
+}
+
+func TestCompleteKMinusE(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		want float64
+	}{
+		{"tiny", 1e-8, 7.853981663426915e-09},
+		{"small", 1e-5, 7.85401108658969e-06},
+		{"milli", 1e-3, 0.000785692871920746},
+		{"tenth", 0.1, 0.0816837118224562},
+		{"half", 0.5, 0.5034307962536965},
+		{"near", 0.99, 2.6796438179646502},
+	}
+
+	t.Log("Given the need to compute K(m) - E(m) without cancellation.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking m=%v.", testID, test.m)
+				{
+					got := mathext.CompleteKMinusE(test.m)
+					if e := relErr(got, test.want); e > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen checking the limits.", len(tt))
+		{
+			if e := relErr(mathext.CompleteKMinusE(1e-300), math.Pi/4*1e-300); e > 1e-15 {
+				t.Fatalf("\t%s\tTest %d:\tShould behave like π/4·m as m goes to 0, rel err %g.", failed, len(tt), e)
+			}
+			if got := mathext.CompleteKMinusE(0); got != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 0 at m=0, got %v.", failed, len(tt), got)
+			}
+			if got := mathext.CompleteKMinusE(1); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get +Inf at m=1, got %v.", failed, len(tt), got)
+			}
+			t.Logf("\t%s\tTest %d:\tShould get the limits.", succeed, len(tt))
+		}
+	}
+}
+
+func TestCompleteEPrimeMinusKPrime(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		want float64
+	}{
+		{"half", 0.5, -0.5034307962536965},
+		{"tenth", 0.9, -0.08168371182245618},
+		{"near", 1 - 1e-8, -7.853981702891282e-09},
+		{"nearer", 1 - 0x1p-30, -7.314590398890389e-10},
+	}
+
+	t.Log("Given the need to compute E(1-m) - K(1-m) without cancellation.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking m=%v.", testID, test.m)
+				{
+					got := mathext.CompleteEPrimeMinusKPrime(test.m)
+					if e := relErr(got, test.want); e > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen checking the limits.", len(tt))
+		{
+			if got := mathext.CompleteEPrimeMinusKPrime(1); got != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 0 at m=1, got %v.", failed, len(tt), got)
+			}
+			if got := mathext.CompleteEPrimeMinusKPrime(0); !math.IsInf(got, -1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get -Inf at m=0, got %v.", failed, len(tt), got)
+			}
+			for _, m := range []float64{0.25, 0.5, 0.75} {
+				if relErr(mathext.CompleteEPrimeMinusKPrime(m), -mathext.CompleteKMinusE(1-m)) > 4e-16 {
+					t.Fatalf("\t%s\tTest %d:\tShould mirror CompleteKMinusE at m=%v.", failed, len(tt), m)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould get the limits.", succeed, len(tt))
+		}
+	}
+}
+
+func TestCompleteBD(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		b, d float64
+	}{
+		{"tiny", 1e-8, 0.785398164379196, 0.7853981663426914},
+		{"tenth", 0.1, 0.7956042304956574, 0.816837118224562},
+		{"half", 0.5, 0.847213084793979, 1.006861592507393},
+		{"high", 0.9, 0.9410728015213956, 1.6370193118267777},
+		{"near", 0.999, 0.9983279862601551, 3.8428045742901418},
+	}
+
+	t.Log("Given the need to evaluate the auxiliary integrals B(m) and D(m).")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking m=%v.", testID, test.m)
+				{
+					if got := mathext.CompleteB(test.m); relErr(got, test.b) > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get B=%v, got %v.", failed, testID, test.b, got)
+					}
+					if got := mathext.CompleteD(test.m); relErr(got, test.d) > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get D=%v, got %v.", failed, testID, test.d, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get B=%v and D=%v.", succeed, testID, test.b, test.d)
+
+					if test.m >= 0.1 {
+						k, e := mathext.CompleteK(test.m), mathext.CompleteE(test.m)
+						if relErr(mathext.CompleteB(test.m), (e-(1-test.m)*k)/test.m) > 1e-14 || relErr(mathext.CompleteD(test.m), (k-e)/test.m) > 1e-14 {
+							t.Fatalf("\t%s\tTest %d:\tShould agree with the combinations of K and E.", failed, testID)
+						}
+						t.Logf("\t%s\tTest %d:\tShould agree with the combinations of K and E.", succeed, testID)
+					}
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen checking the limits.", len(tt))
+		{
+			if b, d := mathext.CompleteB(0), mathext.CompleteD(0); relErr(b, math.Pi/4) > 4e-16 || relErr(d, math.Pi/4) > 4e-16 {
+				t.Fatalf("\t%s\tTest %d:\tShould get π/4 at m=0, got %v and %v.", failed, len(tt), b, d)
+			}
+			if b, d := mathext.CompleteB(1), mathext.CompleteD(1); b != 1 || !math.IsInf(d, 1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get 1 and +Inf at m=1, got %v and %v.", failed, len(tt), b, d)
+			}
+			t.Logf("\t%s\tTest %d:\tShould get the limits.", succeed, len(tt))
+		}
+	}
+}
+
+func TestCompleteKRatio(t *testing.T) {
+	t.Log("Given the need to compute the ratio K(m)/K(1-m) in one pass.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the symmetry of the ratio.")
+		{
+			for _, m := range []float64{0.5, 0.6, 0.9, 0.999, 1 - 1e-12} {
+				if p := mathext.CompleteKRatio(m) * mathext.CompleteKRatio(1-m); math.Abs(p-1) > 1e-15 {
+					t.Fatalf("\t%s\tTest 0:\tShould get a product of 1 at m=%v, got %v.", failed, m, p)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get CompleteKRatio(m)·CompleteKRatio(1-m) = 1.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking coplanar waveguides with singular moduli.")
+		{
+
+			// The singular values k = √2-1 and k = 3-2√2 have K'/K equal to
+			// √2 and 2, the aspect ratios of a CPW with those impedances.
+			k2 := math.Sqrt2 - 1
+			k4 := 1 / (3 + 2*math.Sqrt2)
+			if got := mathext.CompleteKRatio(k2 * k2); relErr(got, 1/math.Sqrt2) > 2e-15 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 1/√2 for k = √2-1, got %v.", failed, got)
+			}
+			if got := mathext.CompleteKRatio(k4 * k4); relErr(got, 0.5) > 2e-15 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 1/2 for k = 3-2√2, got %v.", failed, got)
+			}
+			if got := mathext.CompleteKRatio(0.5); got != 1 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 1 at m = 1/2, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get the known ratios.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking the extremes.")
+		{
+			if got, want := mathext.CompleteKRatio(1e-300), math.Pi/math.Log(16e300); relErr(got, want) > 4e-16 {
+				t.Fatalf("\t%s\tTest 2:\tShould get π/ln(16/m) = %v at m = 1e-300, got %v.", failed, want, got)
+			}
+			for _, m := range []float64{1e-12, 0.3, 0.75, 1 - 1e-9} {
+				if got := mathext.CompleteKRatio(m); relErr(got, 1/mathext.PeriodRatio(m)) > 2e-15 {
+					t.Fatalf("\t%s\tTest 2:\tShould be the reciprocal of PeriodRatio at m=%v, got %v.", failed, m, got)
+				}
+			}
+			if r0, r1 := mathext.CompleteKRatio(0), mathext.CompleteKRatio(1); r0 != 0 || !math.IsInf(r1, 1) {
+				t.Fatalf("\t%s\tTest 2:\tShould get 0 and +Inf at the ends, got %v and %v.", failed, r0, r1)
+			}
+			t.Logf("\t%s\tTest 2:\tShould keep full precision at the extremes.", succeed)
+		}
+	}
+}
+
+func TestParameterZero(t *testing.T) {
+	t.Log("Given the need for the exact closed forms at m = 0.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the complete integrals.")
+		{
+			if k, e := mathext.CompleteK(0), mathext.CompleteE(0); k != math.Pi/2 || e != math.Pi/2 {
+				t.Fatalf("\t%s\tTest 0:\tShould get K = E = π/2, got %v and %v.", failed, k, e)
+			}
+			for n := -4.0; n <= 4; n += 0.125 {
+				want := math.Pi / (2 * math.Sqrt(1-n))
+				switch {
+				case n == 1:
+					want = math.Inf(1)
+				case n > 1:
+					want = 0
+				}
+				if got := mathext.CompletePi(n, 0); got != want {
+					t.Fatalf("\t%s\tTest 0:\tShould get Π(%v|0) = %v, got %v.", failed, n, want, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get π/2 and π/(2√(1-n)).", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the incomplete integrals and the Jacobi functions.")
+		{
+			el := mathext.NewElliptic(0)
+			for x := -10.0; x <= 10; x += 0.01 {
+				if f, e := mathext.EllipticF(x, 0), mathext.EllipticE(x, 0); f != x || e != x || el.F(x) != x || el.E(x) != x {
+					t.Fatalf("\t%s\tTest 1:\tShould get F(φ|0) = E(φ|0) = φ at φ=%v, got %v and %v.", failed, x, f, e)
+				}
+
+				s, c := math.Sincos(x)
+				sn, cn, dn := mathext.Jacobi(x, 0)
+				esn, ecn, edn := el.Jacobi(x)
+				if sn != s || cn != c || dn != 1 || esn != s || ecn != c || edn != 1 {
+					t.Fatalf("\t%s\tTest 1:\tShould get sin, cos and 1 at u=%v, got %v %v %v.", failed, x, sn, cn, dn)
+				}
+
+				wsn, wcn, wdn, eps := mathext.JacobiWithEpsilon(x, 0)
+				if wsn != s || wcn != c || wdn != 1 || eps != x || mathext.JacobiEpsilon(x, 0) != x || mathext.JacobiZn(x, 0) != 0 {
+					t.Fatalf("\t%s\tTest 1:\tShould get ε(u|0) = u and zn(u|0) = 0 at u=%v.", failed, x)
+				}
+				if mathext.JacobiAmplitude(x, 0) != x || el.Amplitude(x) != x {
+					t.Fatalf("\t%s\tTest 1:\tShould get am(u|0) = u at u=%v.", failed, x)
+				}
+			}
+			for s := -1.0; s <= 1; s += 1.0 / 64 {
+				if f, e := mathext.EllipticFSin(s, 0), mathext.EllipticESin(s, 0); f != math.Asin(s) || e != math.Asin(s) {
+					t.Fatalf("\t%s\tTest 1:\tShould get asin s at s=%v, got %v and %v.", failed, s, f, e)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get φ, sin, cos and 1.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking a grid.")
+		{
+			u := []float64{-3, -0.5, 0, 0.5, 3, 100}
+			sn, cn, dn := make([]float64, len(u)), make([]float64, len(u)), make([]float64, len(u))
+			mathext.JacobiGrid(sn, cn, dn, u, 0)
+			for i, v := range u {
+				s, c := math.Sincos(v)
+				if sn[i] != s || cn[i] != c || dn[i] != 1 {
+					t.Fatalf("\t%s\tTest 2:\tShould get sin, cos and 1 at u=%v, got %v %v %v.", failed, v, sn[i], cn[i], dn[i])
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get sin, cos and 1.", succeed)
+		}
+	}
+}
+
+// simpson integrates f over [a, b] with adaptive Simpson quadrature to an
+// absolute tolerance tol. A NaN stops the refinement and is returned.
+func simpson(f func(float64) float64, a, b, tol float64) float64 {
+	var step func(a, b, fa, fm, fb, whole, tol float64, depth int) float64
+	step = func(a, b, fa, fm, fb, whole, tol float64, depth int) float64 {
+		m := (a + b) / 2
+		lm, rm := (a+m)/2, (m+b)/2
+		flm, frm := f(lm), f(rm)
+		left := (m - a) / 6 * (fa + 4*flm + fm)
+		right := (b - m) / 6 * (fm + 4*frm + fb)
+		if depth == 0 || !(math.Abs(left+right-whole) > 15*tol) {
+			return left + right + (left+right-whole)/15
+		}
+		return step(a, m, fa, flm, fm, left, tol/2, depth-1) + step(m, b, fm, frm, fb, right, tol/2, depth-1)
+	}
+
+	fa, fm, fb := f(a), f((a+b)/2), f(b)
+	return step(a, b, fa, fm, fb, (b-a)/6*(fa+4*fm+fb), tol, 50)
+}
+
+func TestCompleteKIntegral(t *testing.T) {
+	t.Log("Given the need to integrate K over [0, m].")
+	{
+		for testID, m := range []float64{0.1, 0.3, 0.5, 0.8, 0.95, 0.99} {
+			t.Logf("\tTest %d:\tWhen checking m=%v.", testID, m)
+			{
+				got, want := mathext.CompleteKIntegral(m), simpson(mathext.CompleteK, 0, m, 1e-15)
+				if e := relErr(got, want); e > 1e-13 {
+					t.Fatalf("\t%s\tTest %d:\tShould match quadrature %v, got %v : rel err %g.", failed, testID, want, got, e)
+				}
+				t.Logf("\t%s\tTest %d:\tShould match quadrature %v.", succeed, testID, want)
+			}
+		}
+
+		t.Logf("\tTest 6:\tWhen checking small m.")
+		{
+			for _, m := range []float64{1e-5, 1e-10, 1e-300, 5e-324} {
+				got := mathext.CompleteKIntegral(m)
+				if want := math.Pi / 2 * m * (1 + m/8 + 3*m*m/64); relErr(got, want) > 4e-16 {
+					t.Fatalf("\t%s\tTest 6:\tShould get π/2·m(1 + m/8 + 3m²/64) = %v at m=%v, got %v.", failed, want, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 6:\tShould behave like π/2·m.", succeed)
+		}
+
+		t.Logf("\tTest 7:\tWhen checking the ends and the domain.")
+		{
+			if a, b := mathext.CompleteKIntegral(0), mathext.CompleteKIntegral(1); a != 0 || b != 2 {
+				t.Fatalf("\t%s\tTest 7:\tShould get 0 and 2 at the ends, got %v and %v.", failed, a, b)
+			}
+			if v := mathext.CompleteKIntegral(math.Nextafter(1, 0)); relErr(v, 2) > 1e-14 {
+				t.Fatalf("\t%s\tTest 7:\tShould approach 2 next to m = 1, got %v.", failed, v)
+			}
+			for _, m := range []float64{-0.1, 1.1, math.NaN()} {
+				if v := mathext.CompleteKIntegral(m); !math.IsNaN(v) {
+					t.Fatalf("\t%s\tTest 7:\tShould get NaN at m=%v, got %v.", failed, m, v)
+				}
+			}
+			t.Logf("\t%s\tTest 7:\tShould handle the ends and reject the rest.", succeed)
+		}
+	}
+}
+
+// weightedQuad integrates P(sin²θ)/√(1 - m sin²θ) over [0, π/2] with the
+// trapezoidal rule. The integrand is even and π-periodic, so the rule
+// converges geometrically.
+func weightedQuad(m float64, coeffs []float64) float64 {
+	const n = 400
+	h := math.Pi / 2 / n
+
+	var sum float64
+	for i := 0; i <= n; i++ {
+		s := math.Sin(float64(i) * h)
+		x := s * s
+		var p float64
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			p = p*x + coeffs[j]
+		}
+		f := p / math.Sqrt(1-m*x)
+		if i == 0 || i == n {
+			f /= 2
+		}
+		sum += f
+	}
+
+	return sum * h
+}
+
+func TestCompleteKWeighted(t *testing.T) {
+	quadratic := []float64{0.5, -2, 3}
+
+	t.Log("Given the need to integrate a polynomial weight against 1/√(1 - m sin²θ).")
+	{
+		for testID, m := range []float64{0, 0.1, 0.5, 0.75, 0.9, 0.99} {
+			t.Logf("\tTest %d:\tWhen checking the weight 1/2 - 2x + 3x² at m=%v.", testID, m)
+			{
+				got, want := mathext.CompleteKWeighted(m, quadratic), weightedQuad(m, quadratic)
+				if e := relErr(got, want); e > 1e-14 {
+					t.Fatalf("\t%s\tTest %d:\tShould match quadrature %v, got %v : rel err %g.", failed, testID, want, got, e)
+				}
+				t.Logf("\t%s\tTest %d:\tShould match quadrature %v.", succeed, testID, want)
+			}
+		}
+
+		t.Logf("\tTest 6:\tWhen checking the lowest moments.")
+		{
+			for _, m := range []float64{0.2, 0.7} {
+				if relErr(mathext.CompleteKWeighted(m, []float64{1}), mathext.CompleteK(m)) > 4e-16 || relErr(mathext.CompleteKWeighted(m, []float64{0, 1}), mathext.CompleteD(m)) > 4e-16 {
+					t.Fatalf("\t%s\tTest 6:\tShould get K and D at m=%v.", failed, m)
+				}
+			}
+			t.Logf("\t%s\tTest 6:\tShould get K and D.", succeed)
+		}
+
+		t.Logf("\tTest 7:\tWhen crossing from the series to the recurrence.")
+		{
+			high := []float64{0, 0, 0, 0, 0, 0, 1}
+			below := mathext.CompleteKWeighted(0.75, high)
+			above := mathext.CompleteKWeighted(math.Nextafter(0.75, 1), high)
+			if relErr(below, above) > 4e-15 {
+				t.Fatalf("\t%s\tTest 7:\tShould be continuous for sin¹²θ, got %v and %v.", failed, below, above)
+			}
+			t.Logf("\t%s\tTest 7:\tShould be continuous.", succeed)
+		}
+
+		t.Logf("\tTest 8:\tWhen checking m = 1 and an empty weight.")
+		{
+			if got := mathext.CompleteKWeighted(1, quadratic); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest 8:\tShould diverge with the sign of P(1), got %v.", failed, got)
+			}
+			if got := mathext.CompleteKWeighted(0.3, nil); got != 0 {
+				t.Fatalf("\t%s\tTest 8:\tShould get 0 for no coefficients, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 8:\tShould handle m = 1 and an empty weight.", succeed)
+		}
+
+		t.Logf("\tTest 9:\tWhen the weight vanishes at m = 1.")
+		{
+			if got := mathext.CompleteKWeighted(1, []float64{1, -1}); got != mathext.CompleteB(1) {
+				t.Fatalf("\t%s\tTest 9:\tShould get B(1) = %v for cos²θ, got %v.", failed, mathext.CompleteB(1), got)
+			}
+
+			// 0.1 + 0.2 - 0.3 rounds to 5.6e-17 rather than 0. The weight
+			// is (1 - x)(0.1 + 0.3x), whose integral against cos θ is 0.2.
+			if got := mathext.CompleteKWeighted(1, []float64{0.1, 0.2, -0.3}); math.Abs(got-0.2) > 2*ulp(0.2) {
+				t.Fatalf("\t%s\tTest 9:\tShould get 0.2 for a rounded zero P(1), got %v.", failed, got)
+			}
+
+			near := mathext.CompleteKWeighted(1-1e-12, []float64{1, -1})
+			if math.Abs(near-1) > 1e-9 {
+				t.Fatalf("\t%s\tTest 9:\tShould approach the limit from below m = 1, got %v.", failed, near)
+			}
+			t.Logf("\t%s\tTest 9:\tShould get the finite limit.", succeed)
+		}
+	}
+}
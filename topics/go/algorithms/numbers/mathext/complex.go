@@ -0,0 +1,114 @@
+package mathext
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// CompleteKComplex computes the complete elliptic integral of the first
+// kind for a complex parameter m.
+//
+//	K(m) = π / (2·AGM(1, √(1-m)))
+//
+// The principal branch is used with the cut along real m > 1. On the cut
+// the sign of the zero imaginary part picks the side, so complex(2, 0)
+// gives the limit from above and complex(2, math.Copysign(0, -1)) the
+// limit from below, which is what CompleteKReciprocal returns. Real m is
+// routed to CompleteKExt.
+func CompleteKComplex(m complex128) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(m) {
+		return cmplx.NaN()
+	}
+	if m == 1 {
+		return cmplx.Inf()
+	}
+
+	// Stay on the real paths when m is real.
+	if x, y := real(m), imag(m); y == 0 {
+
+		// CompleteKExt returns the limit from below past 1.
+		re, im := CompleteKExt(x)
+		if x > 1 {
+			im = math.Copysign(im, y)
+		}
+		return complex(re, im)
+	}
+
+	return complex(math.Pi/2, 0) / agmComplex(1, cmplx.Sqrt(1-m))
+}
+
+// CompleteEComplex computes the complete elliptic integral of the second
+// kind for a complex parameter m.
+//
+//	E(m) = RF(0, 1-m, 1) - m/3·RD(0, 1-m, 1)
+//
+// The branch and its cut along real m > 1 are those of CompleteKComplex,
+// and the sign of a zero imaginary part on the cut again picks the side.
+// There the reciprocal-modulus transformation gives, with r = 1/m,
+//
+//	E(m ± i0) = √m·r(1-r)/3·(RD(0, 1, 1-r) ∓ i·RD(0, 1, r))
+//
+// in which neither part cancels. Real m is routed to CompleteEExt.
+func CompleteEComplex(m complex128) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(m) {
+		return cmplx.NaN()
+	}
+
+	// Stay on the real paths when m is real.
+	if x, y := real(m), imag(m); y == 0 {
+		if math.IsInf(x, 0) {
+			return cmplx.Inf()
+		}
+
+		// CompleteEExt returns the limit from below past 1.
+		re, im := CompleteEExt(x)
+		if x > 1 {
+			im = math.Copysign(im, -y)
+		}
+		return complex(re, im)
+	}
+
+	mc := 1 - m
+	return RFComplex(0, mc, 1) - m/3*RDComplex(0, mc, 1)
+}
+
+// agmComplex computes the arithmetic-geometric mean of a and b choosing
+// at every step the square root that is closer to the arithmetic mean,
+// which gives the value that is continuous in b away from the negative
+// real axis.
+func agmComplex(a, b complex128) complex128 {
+	for i := 0; i < agmMaxIter; i++ {
+		if cmplx.Abs(a-b) <= agmTol*cmplx.Abs(a) {
+			break
+		}
+
+		an := (a + b) / 2
+		bn := cmplx.Sqrt(a * b)
+		if cmplx.Abs(an-bn) > cmplx.Abs(an+bn) {
+			bn = -bn
+		}
+		a, b = an, bn
+	}
+
+	return (a + b) / 2
+}
+
+// CompleteKComplexModulus computes K for a complex elliptic modulus k,
+// that is CompleteKComplex(k²). K depends on k only through k², so k and
+// -k give the same value, including on the cut where real k has |k| > 1.
+// For real k in [-1, 1] the result is CompleteK(k²).
+func CompleteKComplexModulus(k complex128) complex128 {
+
+	// Square real moduli without the signed zero that k·k would carry
+	// into the imaginary part for negative k.
+	if imag(k) == 0 {
+		x := real(k)
+		return CompleteKComplex(complex(x*x, 0))
+	}
+
+	return CompleteKComplex(k * k)
+}
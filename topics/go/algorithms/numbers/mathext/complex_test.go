@@ -0,0 +1,169 @@
+package mathext_test
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestCompleteKComplex(t *testing.T) {
+	tt := []struct {
+		name string
+		m    complex128
+		want complex128
+	}{
+		{"firstQuadrant", 0.3 + 0.4i, 1.65024192564194 + 0.20951070412398676i},
+		{"secondQuadrant", -0.5 + 0.2i, 1.4119905038774165 + 0.04922847659497621i},
+		{"fourthQuadrant", 0.1 - 0.6i, 1.5314497078433944 - 0.22902788393826373i},
+		{"imaginary", 0.5i, 1.521861568515865 + 0.17969676966530013i},
+		{"diagonal", 0.6 + 0.6i, 1.679971641623849 + 0.40318903058600947i},
+	}
+
+	t.Log("Given the need to evaluate K for a complex parameter.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking K(%v).", testID, test.m)
+				{
+					got := mathext.CompleteKComplex(test.m)
+					if e := cmplx.Abs(got-test.want) / cmplx.Abs(test.want); e > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen crossing the cut at m = 2.", len(tt))
+		{
+			re, im := mathext.CompleteKReciprocal(2)
+			below := mathext.CompleteKComplex(complex(2, math.Copysign(0, -1)))
+			above := mathext.CompleteKComplex(2)
+			if below != complex(re, im) || above != complex(re, -im) {
+				t.Fatalf("\t%s\tTest %d:\tShould get %v below and its conjugate above, got %v and %v.", failed, len(tt), complex(re, im), below, above)
+			}
+			near := mathext.CompleteKComplex(2 + 1e-12i)
+			if e := cmplx.Abs(near-above) / cmplx.Abs(above); e > 1e-11 {
+				t.Fatalf("\t%s\tTest %d:\tShould approach %v from above, got %v.", failed, len(tt), above, near)
+			}
+			t.Logf("\t%s\tTest %d:\tShould pick the side from the sign of the zero.", succeed, len(tt))
+		}
+	}
+}
+
+func TestCompleteEComplex(t *testing.T) {
+	tt := []struct {
+		name string
+		m    complex128
+		want complex128
+	}{
+		{"firstQuadrant", 0.3 + 0.4i, 1.462512810717238 - 0.1751606054169262i},
+		{"secondQuadrant", -0.5 + 0.2i, 1.753526785954615 - 0.06710703046639795i},
+		{"fourthQuadrant", 0.1 - 0.6i, 1.5584225309255366 + 0.23775545476219026i},
+		{"imaginary", 0.5i, 1.588255386776217 - 0.1928088131767972i},
+		{"diagonal", 0.6 + 0.6i, 1.3581151449294162 - 0.2957054569316374i},
+		{"pastOne", 3 + 0.5i, 0.650328582855677 - 1.0656836904230147i},
+		{"farOut", -20 - 7i, 4.905448494842393 + 0.7277148354586144i},
+	}
+
+	t.Log("Given the need to evaluate E for a complex parameter.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking E(%v).", testID, test.m)
+				{
+					got := mathext.CompleteEComplex(test.m)
+					if e := cmplx.Abs(got-test.want) / cmplx.Abs(test.want); e > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+
+					if got := mathext.CompleteEComplex(cmplx.Conj(test.m)); got != cmplx.Conj(mathext.CompleteEComplex(test.m)) {
+						t.Fatalf("\t%s\tTest %d:\tShould be real-symmetric, got %v.", failed, testID, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould be real-symmetric.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen m is real.", len(tt))
+		{
+			for _, x := range []float64{-1e6, -3, -0.5, 0, 0.25, 0.9, 1, 1 + 1e-9, 2, 50} {
+				above := mathext.CompleteEComplex(complex(x, 0))
+				below := mathext.CompleteEComplex(complex(x, math.Copysign(0, -1)))
+				if x <= 1 && (above != complex(real(above), 0) || below != above) {
+					t.Fatalf("\t%s\tTest %d:\tShould get a real value at m=%v, got %v and %v.", failed, len(tt), x, above, below)
+				}
+				if x >= 0 && x <= 1 && real(above) != mathext.CompleteE(x) {
+					t.Fatalf("\t%s\tTest %d:\tShould match CompleteE at m=%v, got %v.", failed, len(tt), x, above)
+				}
+				if below != cmplx.Conj(above) {
+					t.Fatalf("\t%s\tTest %d:\tShould get conjugates on the two sides at m=%v, got %v and %v.", failed, len(tt), x, above, below)
+				}
+
+				// A step of 1e-12 off the axis moves E by about that much
+				// times E'(m), which stays below 1 away from m = 1.
+				if x != 1 && x != 1+1e-9 {
+					near := mathext.CompleteEComplex(complex(x, 1e-12*math.Max(1, math.Abs(x))))
+					if e := cmplx.Abs(near-above) / cmplx.Abs(above); e > 1e-11 {
+						t.Fatalf("\t%s\tTest %d:\tShould approach %v from above at m=%v, got %v.", failed, len(tt), above, x, near)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould continue the real values onto both sides of the cut.", succeed, len(tt))
+		}
+
+		t.Logf("\tTest %d:\tWhen m is infinite or NaN.", len(tt)+1)
+		{
+			if got := mathext.CompleteEComplex(complex(math.Inf(-1), 0)); !cmplx.IsInf(got) {
+				t.Fatalf("\t%s\tTest %d:\tShould get Inf at -Inf, got %v.", failed, len(tt)+1, got)
+			}
+			if got := mathext.CompleteEComplex(cmplx.NaN()); !cmplx.IsNaN(got) {
+				t.Fatalf("\t%s\tTest %d:\tShould get NaN, got %v.", failed, len(tt)+1, got)
+			}
+			t.Logf("\t%s\tTest %d:\tShould get Inf and NaN.", succeed, len(tt)+1)
+		}
+	}
+}
+
+func TestCompleteKComplexModulus(t *testing.T) {
+	t.Log("Given the need to evaluate K for a complex modulus.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the selectivity modulus 1/1.5 of an elliptic filter.")
+		{
+			const k = 1 / 1.5
+			got := mathext.CompleteKComplexModulus(k)
+			if want := mathext.CompleteK(k * k); got != complex(want, 0) {
+				t.Fatalf("\t%s\tTest 0:\tShould get %v, got %v.", failed, want, got)
+			}
+			if e := relErr(real(got), 1.8096674954865886); e > 4e-16 {
+				t.Fatalf("\t%s\tTest 0:\tShould get 1.8096674954865886, got %v.", failed, real(got))
+			}
+			t.Logf("\t%s\tTest 0:\tShould match the real path.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking a complex modulus.")
+		{
+			got := mathext.CompleteKComplexModulus(0.5 + 0.5i)
+			want := mathext.CompleteKComplex(0.5i)
+			if got != want {
+				t.Fatalf("\t%s\tTest 1:\tShould get K(k²)=%v, got %v.", failed, want, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get K(k²).", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking that K is even in k.")
+		{
+			for _, k := range []complex128{0.7, 1.5, 0.3 + 1.2i} {
+				if a, b := mathext.CompleteKComplexModulus(k), mathext.CompleteKComplexModulus(-k); a != b {
+					t.Fatalf("\t%s\tTest 2:\tShould get the same value for ±%v, got %v and %v.", failed, k, a, b)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get the same value for k and -k.", succeed)
+		}
+	}
+}
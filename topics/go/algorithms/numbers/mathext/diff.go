@@ -0,0 +1,260 @@
+package mathext
+
+import "math"
+
+// diffSeriesLimit is the parameter below which the second derivatives are
+// summed from their Maclaurin series. Below it the closed forms lose more
+// than a couple of digits to cancellation, above it the series needs more
+// than about 30 terms.
+const diffSeriesLimit = 0.25
+
+// CompleteKDiff computes the derivative of the complete elliptic integral
+// of the first kind with respect to the parameter m.
+//
+//	dK/dm = (E(m) - (1-m)K(m)) / (2m(1-m)) = RD(0, 1, 1-m) / 6
+//
+// The Carlson form has no cancellation and no removable singularity at
+// m = 0. m must be in [0, 1] and the derivative is +Inf at m = 1.
+func CompleteKDiff(m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	return rd(0, 1, 1-m) / 6
+}
+
+// CompleteEDiff computes the derivative of the complete elliptic integral
+// of the second kind with respect to the parameter m.
+//
+//	dE/dm = (E(m) - K(m)) / (2m) = -RD(0, 1-m, 1) / 6
+//
+// m must be in [0, 1] and the derivative is -Inf at m = 1.
+func CompleteEDiff(m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	return -rd(0, 1-m, 1) / 6
+}
+
+// CompleteKPrime computes dK/dm; same as CompleteKDiff. It is not the
+// complementary integral K'(m) = K(1-m) of Elliptic.KPrime and
+// CompleteEPrimeMinusKPrime.
+func CompleteKPrime(m float64) float64 {
+	return CompleteKDiff(m)
+}
+
+// CompleteEPrime computes dE/dm; same as CompleteEDiff. It is not the
+// complementary integral E'(m) = E(1-m) of CompleteAll and
+// CompleteEPrimeMinusKPrime.
+func CompleteEPrime(m float64) float64 {
+	return CompleteEDiff(m)
+}
+
+// CompleteWithDiff computes K(m), E(m), dK/dm and dE/dm together for
+// optimization loops that need the integrals and their gradient at the
+// same m.
+//
+//	dK/dm = RD(0, 1, 1-m)/6,  dE/dm = -RD(0, 1-m, 1)/6
+//
+// The two RD share their duplication steps, so the call costs about one
+// RD more than K and E. K and E are those of CompleteK and CompleteE, and
+// the derivatives can differ from CompleteKDiff and CompleteEDiff by a
+// few ulp, as both are rounded differently. m must be in [0, 1]. At
+// m = 1, K and dK/dm are +Inf, E is 1 and dE/dm is -Inf.
+func CompleteWithDiff(m float64) (k, e, dk, de float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		nan := math.NaN()
+		return nan, nan, nan, nan
+	}
+
+	mc := 1 - m
+	r1, r2 := rdComplete(mc)
+	return completeK(m, mc), completeE(m, mc), r1 / 6, -r2 / 6
+}
+
+// CompleteKEndpoints returns the values of K at the ends of [0, 1] and its
+// one-sided derivative at m = 0,
+//
+//	K(0) = π/2,  K(m) → +Inf as m → 1,  K'(0) = π/8
+//
+// for callers that sample the closed interval and need finite anchors,
+// such as the range of a plot. The derivative at m = 1 is +Inf.
+func CompleteKEndpoints() (atZero, atOneLimit, derivAtZero float64) {
+	return math.Pi / 2, math.Inf(1), math.Pi / 8
+}
+
+// CompleteEEndpoints returns the values of E at the ends of [0, 1] and its
+// one-sided derivative at m = 0,
+//
+//	E(0) = π/2,  E(1) = 1,  E'(0) = -π/8
+//
+// the counterpart of CompleteKEndpoints. E is continuous at m = 1, where
+// its derivative is -Inf.
+func CompleteEEndpoints() (atZero, atOneLimit, derivAtZero float64) {
+	return math.Pi / 2, 1, -math.Pi / 8
+}
+
+// CompleteKContinuation returns K(m) together with the first-order
+// prediction K(m) + dm·dK/dm for K(m + dm), the predictor step of a
+// continuation method that walks m towards 1. m must be in [0, 1]. At
+// m = 1 both values are +Inf.
+func CompleteKContinuation(m, dm float64) (k, kPredicted float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN(), math.NaN()
+	}
+
+	k, dk := completeKWithDiff(m, 1-m)
+	return k, k + dm*dk
+}
+
+// completeKWithDiff evaluates K(m) and dK/dm given both m and its
+// complement mc = 1 - m.
+func completeKWithDiff(m, mc float64) (k, dk float64) {
+	return completeK(m, mc), rd(0, 1, mc) / 6
+}
+
+// CompleteKDiff2 computes the second derivative of K(m) with respect to m.
+// It follows from the hypergeometric equation of K.
+//
+//	d²K/dm² = (K/4 - (1-2m)·dK/dm) / (m(1-m))
+//
+// Near m = 0 the Maclaurin series is summed instead, which also gives the
+// finite limit 9π/64 at m = 0. m must be in [0, 1] and the derivative is
+// +Inf at m = 1.
+func CompleteKDiff2(m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+	if m == 1 {
+		return math.Inf(1)
+	}
+
+	if m < diffSeriesLimit {
+		k2, _ := completeDiff2Series(m)
+		return k2
+	}
+
+	// Written in B(m) and D(m) the numerator is free of the cancellation
+	// between K and dK/dm.
+	mc := 1 - m
+	b, d := completeB(mc), completeD(mc)
+	return ((3*m-1)*b + mc*d) / (4 * m * mc * mc)
+}
+
+// CompleteEDiff2 computes the second derivative of E(m) with respect to m.
+// It follows from the hypergeometric equation of E.
+//
+//	d²E/dm² = -(E/4 + (1-m)·dE/dm) / (m(1-m))
+//
+// Near m = 0 the Maclaurin series is summed instead, which also gives the
+// finite limit -3π/64 at m = 0. m must be in [0, 1]. The derivative
+// diverges like -1/(4(1-m)) as m approaches 1, so -Inf is returned at
+// m = 1.
+func CompleteEDiff2(m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+	if m == 1 {
+		return math.Inf(-1)
+	}
+
+	if m < diffSeriesLimit {
+		_, e2 := completeDiff2Series(m)
+		return e2
+	}
+
+	mc := 1 - m
+	b, d := completeB(mc), completeD(mc)
+	return (mc*d - b) / (4 * m * mc)
+}
+
+// completeDiff2Series sums the Maclaurin series of the second derivatives
+// of K and E. With K = π/2·Σ aₙmⁿ and aₙ = ((1/2)ₙ/n!)² the terms are
+//
+//	d²K/dm² = π/2·Σ (n+2)(n+1)·aₙ₊₂·mⁿ
+//	d²E/dm² = -π/2·Σ (n+2)(n+1)·aₙ₊₂/(2n+3)·mⁿ
+//
+// and consecutive terms follow from the ratio of the aₙ.
+func completeDiff2Series(m float64) (k2, e2 float64) {
+	t := 9.0 / 32
+	for n := 0.0; ; n++ {
+		k2 += t
+		e2 -= t / (2*n + 3)
+		if t <= 0x1p-54*k2 {
+			break
+		}
+		r := (2*n + 5) / (2*n + 6)
+		t *= (n + 3) / (n + 1) * r * r * m
+	}
+
+	return math.Pi / 2 * k2, math.Pi / 2 * e2
+}
+
+// piDiffSeriesLimit is the magnitude of n below which CompletePiDiffN sums
+// the series in n. The 36 terms it keeps reach working precision there.
+const piDiffSeriesLimit = 0.25
+
+// CompletePiDiffN computes the derivative of the complete elliptic
+// integral of the third kind with respect to the characteristic n.
+//
+//	∂Π/∂n = ∫₀^{π/2} sin²θ / ((1 - n sin²θ)²√(1 - m sin²θ)) dθ
+//	      = (E(m) + (m-n)K(m)/n + (n²-m)Π(n|m)/n) / (2(m-n)(n-1))
+//
+// The closed form has removable singularities at n = 0 and n = m. For
+// |n| < 1/4 the integrand is expanded instead, ∂Π/∂n = Σ j·nʲ⁻¹·Jⱼ(m)
+// with the moments Jⱼ of CompleteKWeighted, which gives D(m) at n = 0. At
+// n = m the integrand is sin²θ/Δ⁵ and the limit
+//
+//	((1+m)E(m) - (1-m)K(m)) / (3m(1-m)²)
+//
+// is returned, but close to it the closed form cancels and loses about
+// log₁₀(1/|m-n|) digits. The derivative is +Inf at the pole n = 1 and,
+// for n < 1, at m = 1. m must be in [0, 1] and for n > 1 the derivative
+// of the principal value is returned.
+func CompletePiDiffN(n, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(n) || math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	switch {
+	case n == 1:
+		return math.Inf(1)
+	case m == 1:
+		if n < 1 {
+			return math.Inf(1)
+		}
+		return math.NaN()
+	case math.Abs(n) < piDiffSeriesLimit:
+		var c [36]float64
+		p := 1.0
+		for j := 1; j < len(c); j++ {
+			c[j] = float64(j) * p
+			p *= n
+		}
+		return CompleteKWeighted(m, c[:])
+	}
+
+	mc := 1 - m
+	k, e := completeK(m, mc), completeE(m, mc)
+	if n == m {
+		return ((1+m)*e - mc*k) / (3 * m * mc * mc)
+	}
+
+	pi := rf(0, mc, 1) + n/3*rj(0, mc, 1, 1-n)
+	return (e + (m-n)*k/n + (n*n-m)*pi/n) / (2 * (m - n) * (n - 1))
+}
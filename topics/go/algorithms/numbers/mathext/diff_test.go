@@ -0,0 +1,336 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestCompleteDiff(t *testing.T) {
+	tt := []struct {
+		name   string
+		m      float64
+		k1, e1 float64
+		k2, e2 float64
+	}{
+		{"zero", 0, math.Pi / 8, -math.Pi / 8, 9 * math.Pi / 64, -3 * math.Pi / 64},
+		{"tenth", 0.1, 0.4420023502753653, -0.408418559112281, 0.5500939662195851, -0.16791895581542132},
+		{"seriesEdge", 0.25, 0.5417318486132803, -0.43657629094633776, 0.8030488767813805, -0.2103111153338851},
+		{"half", 0.5, 0.847213084793979, -0.5034307962536965, 1.8540746773013719, -0.34378228854028264},
+		{"high", 0.9, 4.7053640076069785, -0.8185096559133889, 48.98682482691808, -2.159363528718661},
+		{"near", 0.99, 49.446321787642646, -1.3533554636185103, 4988.01057501386, -24.289376931325318},
+	}
+
+	t.Log("Given the need to differentiate the complete integrals with respect to m.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking the derivatives at m=%v.", testID, test.m)
+				{
+					got := []float64{
+						mathext.CompleteKDiff(test.m),
+						mathext.CompleteEDiff(test.m),
+						mathext.CompleteKDiff2(test.m),
+						mathext.CompleteEDiff2(test.m),
+					}
+					want := []float64{test.k1, test.e1, test.k2, test.e2}
+					for i := range got {
+						if e := relErr(got[i], want[i]); e > 2e-15 {
+							t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, want[i], got[i], e)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould get the reference values.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCompleteDiffFiniteDifference(t *testing.T) {
+	const h = 0x1p-17
+
+	fns := []struct {
+		name string
+		f    func(float64) float64
+		df   func(float64) float64
+	}{
+		{"K", mathext.CompleteK, mathext.CompleteKDiff},
+		{"E", mathext.CompleteE, mathext.CompleteEDiff},
+		{"KDiff", mathext.CompleteKDiff, mathext.CompleteKDiff2},
+		{"EDiff", mathext.CompleteEDiff, mathext.CompleteEDiff2},
+	}
+
+	t.Log("Given the need to keep each derivative consistent with its function.")
+	{
+		for testID, test := range fns {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen comparing d%s/dm with a central difference.", testID, test.name)
+				{
+					for _, m := range []float64{0.05, 0.2, 0.25, 0.3, 0.6, 0.85, 0.9} {
+						want := (test.f(m+h) - test.f(m-h)) / (2 * h)
+						got := test.df(m)
+						if e := relErr(got, want); e > 1e-8 {
+							t.Fatalf("\t%s\tTest %d:\tShould get about %v at m=%v, got %v : rel err %g.", failed, testID, want, m, got, e)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould match the central difference.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCompleteDiffEdges(t *testing.T) {
+	t.Log("Given the need to handle the edges of the domain.")
+	{
+		t.Logf("\tTest 0:\tWhen checking m = 1.")
+		{
+			if got := mathext.CompleteKDiff(1); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest 0:\tShould get +Inf for dK/dm, got %v.", failed, got)
+			}
+			if got := mathext.CompleteEDiff(1); !math.IsInf(got, -1) {
+				t.Fatalf("\t%s\tTest 0:\tShould get -Inf for dE/dm, got %v.", failed, got)
+			}
+			if got := mathext.CompleteKDiff2(1); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest 0:\tShould get +Inf for d²K/dm², got %v.", failed, got)
+			}
+			if got := mathext.CompleteEDiff2(1); !math.IsInf(got, -1) {
+				t.Fatalf("\t%s\tTest 0:\tShould get -Inf for d²E/dm², got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 0:\tShould diverge.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking m outside of [0, 1].")
+		{
+			fns := []func(float64) float64{mathext.CompleteKDiff, mathext.CompleteEDiff, mathext.CompleteKDiff2, mathext.CompleteEDiff2}
+			for _, f := range fns {
+				for _, m := range []float64{-0.5, 1.5, math.NaN()} {
+					if got := f(m); !math.IsNaN(got) {
+						t.Fatalf("\t%s\tTest 1:\tShould get NaN for m=%v, got %v.", failed, m, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get NaN.", succeed)
+		}
+
+		// 60 digit values of RD(0, 1, 1-m)/6 and -RD(0, 1-m, 1)/6. The
+		// closed forms in K and E divide a cancelling difference by m or
+		// 1-m here, the Carlson forms do not.
+		limits := []struct {
+			m, k1, e1 float64
+		}{
+			{1e-300, 0.39269908169872414, -0.39269908169872414},
+			{1e-8, 0.3926990861165889, -0.3926990831713457},
+			{1 - 0x1p-27, 67108861.68905465, -4.871890675632065},
+			{1 - 0x1p-50, 562949953421307.7, -8.857486937559267},
+		}
+
+		t.Logf("\tTest 2:\tWhen m approaches 0 and 1.")
+		{
+			for _, l := range limits {
+				if got := mathext.CompleteKDiff(l.m); math.Abs(got-l.k1) > 2*ulp(l.k1) {
+					t.Fatalf("\t%s\tTest 2:\tShould get dK/dm = %v at m=%v, got %v.", failed, l.k1, l.m, got)
+				}
+				if got := mathext.CompleteEDiff(l.m); math.Abs(got-l.e1) > 2*ulp(l.e1) {
+					t.Fatalf("\t%s\tTest 2:\tShould get dE/dm = %v at m=%v, got %v.", failed, l.e1, l.m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get the reference values to two ulp.", succeed)
+		}
+
+		t.Logf("\tTest 3:\tWhen calling the Prime names.")
+		{
+			for _, m := range []float64{0, 1e-300, 0.3, 1 - 0x1p-50, 1, 1.5} {
+				if got, want := mathext.CompleteKPrime(m), mathext.CompleteKDiff(m); got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+					t.Fatalf("\t%s\tTest 3:\tShould get dK/dm = %v at m=%v, got %v.", failed, want, m, got)
+				}
+				if got, want := mathext.CompleteEPrime(m), mathext.CompleteEDiff(m); got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+					t.Fatalf("\t%s\tTest 3:\tShould get dE/dm = %v at m=%v, got %v.", failed, want, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 3:\tShould match CompleteKDiff and CompleteEDiff.", succeed)
+		}
+	}
+}
+
+func TestCompleteEndpoints(t *testing.T) {
+	t.Log("Given the need for the values of K and E at the ends of [0, 1].")
+	{
+		t.Logf("\tTest 0:\tWhen checking K.")
+		{
+			k0, k1, dk0 := mathext.CompleteKEndpoints()
+			if k0 != math.Pi/2 || !math.IsInf(k1, 1) || dk0 != math.Pi/8 {
+				t.Fatalf("\t%s\tTest 0:\tShould get π/2, +Inf and π/8, got %v, %v and %v.", failed, k0, k1, dk0)
+			}
+			if k0 != mathext.CompleteK(0) || k1 != mathext.CompleteK(1) || relErr(dk0, mathext.CompleteKDiff(0)) > 4e-16 {
+				t.Fatalf("\t%s\tTest 0:\tShould agree with CompleteK and CompleteKDiff.", failed)
+			}
+			if d := (mathext.CompleteK(0x1p-30) - k0) / 0x1p-30; relErr(d, dk0) > 1e-6 {
+				t.Fatalf("\t%s\tTest 0:\tShould match the one-sided difference quotient %v.", failed, d)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get π/2, +Inf and π/8.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking E.")
+		{
+			e0, e1, de0 := mathext.CompleteEEndpoints()
+			if e0 != math.Pi/2 || e1 != 1 || de0 != -math.Pi/8 {
+				t.Fatalf("\t%s\tTest 1:\tShould get π/2, 1 and -π/8, got %v, %v and %v.", failed, e0, e1, de0)
+			}
+			if e0 != mathext.CompleteE(0) || e1 != mathext.CompleteE(1) || relErr(de0, mathext.CompleteEDiff(0)) > 4e-16 {
+				t.Fatalf("\t%s\tTest 1:\tShould agree with CompleteE and CompleteEDiff.", failed)
+			}
+			if e := mathext.CompleteE(math.Nextafter(1, 0)); math.Abs(e-e1) > 1e-14 {
+				t.Fatalf("\t%s\tTest 1:\tShould approach 1 next to m = 1, got %v.", failed, e)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get π/2, 1 and -π/8.", succeed)
+		}
+	}
+}
+
+func TestCompleteKContinuation(t *testing.T) {
+	t.Log("Given the need to predict K one continuation step ahead.")
+	{
+		for testID, m := range []float64{0.1, 0.5, 0.8, 0.95} {
+			t.Logf("\tTest %d:\tWhen stepping from m=%v.", testID, m)
+			{
+				k, _ := mathext.CompleteKContinuation(m, 0)
+				if k != mathext.CompleteK(m) {
+					t.Fatalf("\t%s\tTest %d:\tShould get K(m)=%v, got %v.", failed, testID, mathext.CompleteK(m), k)
+				}
+
+				// Halving the step must cut the prediction error by about
+				// four for a second order remainder.
+				errAt := func(dm float64) float64 {
+					_, p := mathext.CompleteKContinuation(m, dm)
+					return math.Abs(p - mathext.CompleteK(m+dm))
+				}
+				for _, dm := range []float64{1e-3, -1e-3, 1e-4} {
+					r := errAt(dm) / errAt(dm/2)
+					if r < 3.5 || r > 4.5 {
+						t.Fatalf("\t%s\tTest %d:\tShould shrink the error by 4 when halving dm=%v, got %v.", failed, testID, dm, r)
+					}
+				}
+				t.Logf("\t%s\tTest %d:\tShould have an O(dm²) prediction error.", succeed, testID)
+			}
+		}
+	}
+}
+
+func TestCompletePiDiffN(t *testing.T) {
+	tt := []struct {
+		name string
+		n, m float64
+		want float64
+	}{
+		{"closedForm", 0.3, 0.5, 1.7457904431242988},
+		{"negative", -0.5, 0.2, 0.4615170120722345},
+		{"aboveM", 0.9, 0.1, 26.097603540026228},
+		{"principalValue", 2, 0.5, 0.19620510766414184},
+		{"series", 0.05, 0.3, 0.9677957175365723},
+		{"seriesNegative", -0.05, 0.9, 1.5105031967897329},
+		{"nearPole", 0.999, 0.99, 240686.89123193422},
+	}
+
+	t.Log("Given the need to differentiate the integral of the third kind with respect to n.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking n=%v, m=%v.", testID, test.n, test.m)
+				{
+					got := mathext.CompletePiDiffN(test.n, test.m)
+					if e := relErr(got, test.want); e > 4e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+
+					// Five-point central difference of CompletePi with a step
+					// that shrinks towards the pole.
+					h := 1e-3 * math.Abs(1-test.n)
+					pi := func(n float64) float64 { return mathext.CompletePi(n, test.m) }
+					fd := (8*(pi(test.n+h)-pi(test.n-h)) - (pi(test.n+2*h) - pi(test.n-2*h))) / (12 * h)
+					if e := relErr(got, fd); e > 1e-9 {
+						t.Fatalf("\t%s\tTest %d:\tShould match the finite difference %v : rel err %g.", failed, testID, fd, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould match the finite difference.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen checking the removable singularities and the pole.", len(tt))
+		{
+			if got, want := mathext.CompletePiDiffN(0, 0.4), mathext.CompleteD(0.4); relErr(got, want) > 4e-16 {
+				t.Fatalf("\t%s\tTest %d:\tShould get D(m) at n = 0, got %v want %v.", failed, len(tt), got, want)
+			}
+			if got := mathext.CompletePiDiffN(0.5, 0.5); relErr(got, 2.9304759544555394) > 4e-16 {
+				t.Fatalf("\t%s\tTest %d:\tShould get the limit at n = m, got %v.", failed, len(tt), got)
+			}
+			if got := mathext.CompletePiDiffN(0.5+1e-6, 0.5); relErr(got, mathext.CompletePiDiffN(0.5, 0.5)) > 1e-5 {
+				t.Fatalf("\t%s\tTest %d:\tShould be continuous at n = m, got %v.", failed, len(tt), got)
+			}
+			if got := mathext.CompletePiDiffN(1, 0.5); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get +Inf at n = 1, got %v.", failed, len(tt), got)
+			}
+			if got := mathext.CompletePiDiffN(0.5, 1); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest %d:\tShould get +Inf at m = 1, got %v.", failed, len(tt), got)
+			}
+			t.Logf("\t%s\tTest %d:\tShould get the limits.", succeed, len(tt))
+		}
+	}
+}
+
+func TestCompleteWithDiff(t *testing.T) {
+	t.Log("Given the need for K, E and their derivatives at the same m.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with the separate functions.")
+		{
+			for i := 0; i <= 1000; i++ {
+				for _, m := range []float64{float64(i) / 1000, 1 - math.Ldexp(1, -i%60), math.Ldexp(1, -i%1000)} {
+					k, e, dk, de := mathext.CompleteWithDiff(m)
+					if k != mathext.CompleteK(m) || e != mathext.CompleteE(m) {
+						t.Fatalf("\t%s\tTest 0:\tShould match CompleteK and CompleteE at m=%v, got %v and %v.", failed, m, k, e)
+					}
+					if want := mathext.CompleteKDiff(m); math.Abs(dk-want) > 4*ulp(want) {
+						t.Fatalf("\t%s\tTest 0:\tShould get dK/dm = %v at m=%v, got %v.", failed, want, m, dk)
+					}
+					if want := mathext.CompleteEDiff(m); math.Abs(de-want) > 4*ulp(want) {
+						t.Fatalf("\t%s\tTest 0:\tShould get dE/dm = %v at m=%v, got %v.", failed, want, m, de)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould agree within the error of both.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen m is at the ends of or outside of [0, 1].")
+		{
+			if k, e, dk, de := mathext.CompleteWithDiff(0); k != math.Pi/2 || e != math.Pi/2 || relErr(dk, math.Pi/8) > 2e-16 || relErr(de, -math.Pi/8) > 2e-16 {
+				t.Fatalf("\t%s\tTest 1:\tShould get π/2, π/2, π/8 and -π/8 at m = 0, got %v, %v, %v and %v.", failed, k, e, dk, de)
+			}
+			if k, e, dk, de := mathext.CompleteWithDiff(1); !math.IsInf(k, 1) || e != 1 || !math.IsInf(dk, 1) || !math.IsInf(de, -1) {
+				t.Fatalf("\t%s\tTest 1:\tShould get +Inf, 1, +Inf and -Inf at m = 1, got %v, %v, %v and %v.", failed, k, e, dk, de)
+			}
+			for _, m := range []float64{-0.5, 1.5, math.NaN()} {
+				if k, e, dk, de := mathext.CompleteWithDiff(m); !math.IsNaN(k) || !math.IsNaN(e) || !math.IsNaN(dk) || !math.IsNaN(de) {
+					t.Fatalf("\t%s\tTest 1:\tShould get NaN for m=%v.", failed, m)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get the limits and NaN.", succeed)
+		}
+	}
+}
+
+func BenchmarkCompleteWithDiff(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		k, _, _, _ = mathext.CompleteWithDiff(0.3)
+	}
+}
+
+func BenchmarkCompleteFourCallsWithDiff(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		k = mathext.CompleteK(0.3) + mathext.CompleteE(0.3) + mathext.CompleteKDiff(0.3) + mathext.CompleteEDiff(0.3)
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Package mathext implements elliptic integrals and the special functions
+built on top of them.
+
+# Elliptic integrals
+
+The integrals in this package use the parameter convention m = k², where
+k is the elliptic modulus. Functions return NaN when an argument falls
+outside of the domain where the integral is real and finite.
+
+# Carlson symmetric forms
+
+All of the Legendre forms can be rewritten in terms of a small set of
+symmetric integrals that are evaluated with the duplication theorem.
+
+	RF(x, y, z)    = ½ ∫₀^∞ dt / √((t+x)(t+y)(t+z))
+	RJ(x, y, z, p) = 3/2 ∫₀^∞ dt / ((t+p)√((t+x)(t+y)(t+z)))
+	RD(x, y, z)    = RJ(x, y, z, z)
+	RC(x, y)       = RF(x, y, y)
+
+Resources:
+
+https://dlmf.nist.gov/19
+
+B. C. Carlson, Numerical computation of real or complex elliptic
+integrals, Numerical Algorithms 10 (1995), 13-26.
+https://arxiv.org/abs/math/9409227
+*/
+package mathext
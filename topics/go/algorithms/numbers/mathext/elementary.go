@@ -0,0 +1,83 @@
+package mathext
+
+import "math"
+
+// AtanhRC computes the inverse hyperbolic tangent from the degenerate
+// Carlson integral.
+//
+//	atanh(x) = x·RC(1, 1-x²)
+//
+// The small argument behaviour atanh(x) ≈ x is carried by the factor x, so
+// there is no cancellation near 0. x must be in [-1, 1] and atanh(±1) is
+// ±Inf.
+func AtanhRC(x float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || x < -1 || x > 1 {
+		return math.NaN()
+	}
+	if x == 0 {
+		return x
+	}
+
+	// 1 - x² is formed as (1-x)(1+x), which is exact to an ulp close to
+	// |x| = 1.
+	return x * rc(1, (1-x)*(1+x))
+}
+
+// AtanRC computes the inverse tangent from the degenerate Carlson
+// integral.
+//
+//	atan(x) = x·RC(1, 1+x²)
+//
+// For |x| > 1 the homogeneity of RC turns this into
+// atan(x) = sign(x)·RC(1/x², 1+1/x²), which avoids overflow in x² and
+// gives ±π/2 at ±Inf.
+func AtanRC(x float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 {
+		return x
+	}
+
+	if math.Abs(x) <= 1 {
+		return x * rc(1, 1+x*x)
+	}
+
+	r := 1 / (x * x)
+	return math.Copysign(rc(r, 1+r), x)
+}
+
+// LogRC computes the natural logarithm from the degenerate Carlson
+// integral.
+//
+//	ln(x) = (x-1)·RC(((1+x)/2)², x)
+//
+// x - 1 is exact close to 1, so the result keeps its relative precision
+// where ln(x) goes through zero. Arguments far from 1 are split into a
+// mantissa and a power of two first to keep ((1+x)/2)² finite. x must be
+// non-negative and ln(0) = -Inf.
+func LogRC(x float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || x < 0 {
+		return math.NaN()
+	}
+	if x == 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(x, 1) {
+		return x
+	}
+
+	if x < 0x1p-500 || x > 0x1p500 {
+		f, e := math.Frexp(x)
+		return LogRC(f) + float64(e)*math.Ln2
+	}
+
+	t := (1 + x) / 2
+	return (x - 1) * rc(t*t, x)
+}
@@ -0,0 +1,78 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestElementaryRC(t *testing.T) {
+	fns := []struct {
+		name string
+		rc   func(float64) float64
+		std  func(float64) float64
+		x    []float64
+	}{
+		{"Atanh", mathext.AtanhRC, math.Atanh, []float64{-0.999999, -0.5, -1e-8, 1e-300, 1e-20, 1e-5, 0.1, 0.5, 0.9, 0.999999}},
+		{"Atan", mathext.AtanRC, math.Atan, []float64{-1e10, -3, -1e-8, 1e-300, 1e-20, 1e-5, 0.3, 1, 2, 1e200}},
+		{"Log", mathext.LogRC, math.Log, []float64{1e-300, 1e-5, 0.5, 0.999999, 1 + 1e-12, 1.5, 10, 1e5, 1e200}},
+	}
+
+	t.Log("Given the need to evaluate elementary functions from RC.")
+	{
+		for testID, test := range fns {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen comparing %sRC with the math package.", testID, test.name)
+				{
+					for _, x := range test.x {
+						got, want := test.rc(x), test.std(x)
+						if e := relErr(got, want); e > 1e-15 {
+							t.Fatalf("\t%s\tTest %d:\tShould get %v at x=%v, got %v : rel err %g.", failed, testID, want, x, got, e)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould match the math package.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestElementaryRCSmall(t *testing.T) {
+	t.Log("Given the need to keep full precision close to the zeros.")
+	{
+		t.Logf("\tTest 0:\tWhen checking small arguments.")
+		{
+			for _, x := range []float64{1e-5, 1e-8, 1e-15, 1e-100} {
+				if e := relErr(mathext.AtanhRC(x), x+x*x*x/3); e > 4e-16 {
+					t.Fatalf("\t%s\tTest 0:\tShould get atanh(%v) to an ulp, rel err %g.", failed, x, e)
+				}
+				if e := relErr(mathext.AtanRC(x), x-x*x*x/3); e > 4e-16 {
+					t.Fatalf("\t%s\tTest 0:\tShould get atan(%v) to an ulp, rel err %g.", failed, x, e)
+				}
+				if e := relErr(mathext.LogRC(1+x), math.Log1p((1+x)-1)); e > 4e-16 {
+					t.Fatalf("\t%s\tTest 0:\tShould get log(1+%v) to an ulp, rel err %g.", failed, x, e)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould not lose digits to cancellation.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the edges of the domains.")
+		{
+			if got := mathext.AtanhRC(1); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest 1:\tShould get +Inf for atanh(1), got %v.", failed, got)
+			}
+			if got := mathext.AtanRC(math.Inf(-1)); got != -math.Pi/2 {
+				t.Fatalf("\t%s\tTest 1:\tShould get -π/2 for atan(-Inf), got %v.", failed, got)
+			}
+			if got := mathext.LogRC(0); !math.IsInf(got, -1) {
+				t.Fatalf("\t%s\tTest 1:\tShould get -Inf for log(0), got %v.", failed, got)
+			}
+			if !math.IsNaN(mathext.AtanhRC(1.5)) || !math.IsNaN(mathext.LogRC(-1)) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN outside of the domain.", failed)
+			}
+			t.Logf("\t%s\tTest 1:\tShould handle the edges.", succeed)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package mathext
+
+import (
+	"fmt"
+	"math"
+)
+
+// ellipseMaxIter bounds the safeguarded Newton iteration that inverts the
+// arc length. Each bisection halves the bracket, so this is enough to
+// pin the angle to the last bit even when every Newton step is rejected.
+const ellipseMaxIter = 64
+
+// ellipseArcMaxSegments is the most segments NewEllipseArcIterator splits
+// the perimeter into. It keeps the count representable in an int on every
+// platform.
+const ellipseArcMaxSegments = 1 << 30
+
+// EllipseArcLength computes the arc length of the ellipse
+// x = a·cos t, y = b·sin t between t = 0 and t = θ.
+//
+//	s(θ) = ∫₀^θ √(a² sin²t + b² cos²t) dt
+//
+// The length is measured from the end of the a axis and is odd in θ with
+// s(θ + kπ) = s(θ) + k·P/2, where P is the perimeter. a and b must be
+// non-negative and finite. The evaluation is scaled by the longer
+// semi-axis and stays accurate for any aspect ratio, down to the segment
+// b = 0 whose perimeter is 4a.
+func EllipseArcLength(a, b, theta float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsNaN(theta) || math.IsInf(theta, 0) || a < 0 || b < 0 || math.IsInf(a, 1) || math.IsInf(b, 1) {
+		return math.NaN()
+	}
+
+	// Reduce θ to [-π/2, π/2] and remember how many half turns were
+	// removed.
+	theta, k := reduceHalfPeriods(theta)
+	s, c := math.Sincos(theta)
+
+	// Treat the float64 closest to ±π/2 as the end of the b axis, so the
+	// quarter perimeter is computed the same way everywhere and the
+	// inverse maps it back to π/2 regardless of rounding in cos θ.
+	if math.Abs(theta) == math.Pi/2 {
+		s, c = math.Copysign(1, theta), 0
+	}
+
+	l := ellipseArc(a, b, s, c)
+	if k != 0 {
+		l += 2 * k * ellipseArc(a, b, 1, 0)
+	}
+
+	return l
+}
+
+// EllipsePerimeter computes the perimeter of the ellipse with the
+// semi-axes a and b,
+//
+//	P = 4·max(a, b)·E(1 - r²),  r = min(a, b)/max(a, b)
+//
+// in the parameter convention of CompleteE, where the argument is the
+// squared eccentricity and not the eccentricity itself. 1 - r² is formed
+// as (1 - r)(1 + r) and passed on together with r², so nearly circular
+// ellipses approach 2πa and needle-thin ones 4a without cancellation, and
+// the semi-axes are never squared, so they may span the whole float64
+// range. a and b must be non-negative and finite.
+func EllipsePerimeter(a, b float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(a) || math.IsNaN(b) || a < 0 || b < 0 || math.IsInf(a, 1) || math.IsInf(b, 1) {
+		return math.NaN()
+	}
+
+	return 4 * ellipseQuarter(a, b)
+}
+
+// ellipseArc evaluates the arc length up to the angle with sine s and
+// cosine c in [-π/2, π/2]. It is the homogeneous Carlson form of
+// b·E(θ|1 - a²/b²)
+//
+//	s(θ) = b²·sin θ·(RF(b²cos²θ, Δ², b²) + (a² - b²)/3·sin²θ·RD(b²cos²θ, Δ², b²))
+//
+// with Δ² = a² sin²θ + b² cos²θ, the square of the integrand. The longer
+// semi-axis is scaled to 1 first, so the squares neither overflow nor
+// underflow, and a b too short to square is treated as the segment
+// s(θ) = a(1 - cos θ) that the ellipse collapses to.
+func ellipseArc(a, b, s, c float64) float64 {
+	switch {
+	case s == 0:
+		return 0
+	case c == 0:
+		return math.Copysign(ellipseQuarter(a, b), s)
+	}
+
+	h := math.Max(a, b)
+	if h == 0 {
+		return 0
+	}
+	a, b = a/h, b/h
+
+	// 1 - cos θ = sin²θ/(1 + cos θ) keeps its precision for small θ.
+	if b < 0x1p-511 {
+		return h * a * s * math.Abs(s) / (1 + c)
+	}
+
+	a2, b2 := a*a, b*b
+	x := b2 * c * c
+	d2 := a2*s*s + x
+
+	return h * b2 * s * (rf(x, d2, b2) + (a2-b2)/3*s*s*rd(x, d2, b2))
+}
+
+// ellipseQuarter computes the quarter perimeter of the ellipse with the
+// semi-axes a and b. With r the ratio of the shorter to the longer one it
+// is
+//
+//	max(a, b)·E(1 - r²)
+//
+// where 1 - r² is formed as (1 - r)(1 + r) and the complement r² is passed
+// on as well, so nearly circular ellipses and needle-thin ones both keep
+// their precision. r² underflowing to 0 gives the limit 4·max(a, b) for
+// the perimeter.
+func ellipseQuarter(a, b float64) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	if a == 0 {
+		return 0
+	}
+
+	r := b / a
+	return a * completeE((1-r)*(1+r), r*r)
+}
+
+// EllipseAngleForArcLength computes the parametric angle θ at which the
+// arc of the ellipse x = a·cos t, y = b·sin t measured from t = 0 reaches
+// the length s. It is the inverse of EllipseArcLength. s is wrapped into
+// [0, P) first, where P is the perimeter, so the result is in [0, 2π).
+// a and b must be positive.
+func EllipseAngleForArcLength(a, b, s float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsNaN(s) || math.IsInf(s, 0) || a <= 0 || b <= 0 {
+		return math.NaN()
+	}
+
+	theta, _, _ := ellipseAngleForArcLength(a, b, s, ellipseMaxIter)
+	return theta
+}
+
+// EllipseAngleForArcLengthErr computes EllipseAngleForArcLength and returns
+// an error for arguments outside of the domain or when the iteration did
+// not meet its tolerance within ellipseMaxIter steps, in which case θ is
+// the last iterate and the error names the residual of the arc length.
+func EllipseAngleForArcLengthErr(a, b, s float64) (theta float64, err error) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsNaN(s) || math.IsInf(s, 0) || a <= 0 || b <= 0 {
+		return math.NaN(), fmt.Errorf("mathext: EllipseAngleForArcLength needs positive axes and a finite length, got a=%v b=%v s=%v", a, b, s)
+	}
+
+	theta, r, ok := ellipseAngleForArcLength(a, b, s, ellipseMaxIter)
+	if !ok {
+		return theta, fmt.Errorf("mathext: EllipseAngleForArcLength did not converge at a=%v b=%v s=%v: residual %g", a, b, s, r)
+	}
+
+	return theta, nil
+}
+
+// ellipseAngleForArcLength inverts the arc length with at most maxIter
+// steps of ellipseAngle. It also returns the residual of the arc length
+// and whether the iteration met its tolerance.
+func ellipseAngleForArcLength(a, b, s float64, maxIter int) (float64, float64, bool) {
+
+	// Wrap s into one turn of the ellipse.
+	q := ellipseArc(a, b, 1, 0)
+	s = math.Mod(s, 4*q)
+	if s < 0 {
+		s += 4 * q
+	}
+
+	// The second half of the ellipse repeats the first, shifted by π.
+	var base float64
+	if s >= 2*q {
+		base = math.Pi
+		s -= 2 * q
+	}
+
+	// The arc is symmetric about the end of the b axis, so a length in
+	// the second quadrant is found from its distance to the half turn.
+	switch {
+	case s == q:
+		return base + math.Pi/2, 0, true
+	case s > q:
+		theta, r, ok := ellipseAngle(a, b, 2*q-s, q, maxIter)
+		return base + math.Pi - theta, -r, ok
+	}
+
+	theta, r, ok := ellipseAngle(a, b, s, q, maxIter)
+	return base + theta, r, ok
+}
+
+// ellipseAngle finds θ in [0, π/2] with s(θ) = s for a length s in
+// [0, q], where q is the quarter perimeter. It runs Newton's method on the
+// arc length, whose derivative is the integrand, and falls back to
+// bisection whenever a step leaves the bracket. It also returns the
+// residual s(θ) - s of the last step and whether the step size met the
+// tolerance within maxIter steps.
+func ellipseAngle(a, b, s, q float64, maxIter int) (float64, float64, bool) {
+	if s == 0 {
+		return 0, 0, true
+	}
+
+	// Start from the angle the circle with the same quarter perimeter
+	// would give.
+	lo, hi := 0.0, math.Pi/2
+	theta := s / q * math.Pi / 2
+
+	var f float64
+	for i := 0; i < maxIter; i++ {
+		sn, cn := math.Sincos(theta)
+		f = ellipseArc(a, b, sn, cn) - s
+		if f == 0 {
+			return theta, 0, true
+		}
+
+		// Shrink the bracket around the root.
+		if f < 0 {
+			lo = theta
+		} else {
+			hi = theta
+		}
+
+		// Take the Newton step if it stays inside the bracket and bisect
+		// otherwise.
+		next := theta - f/math.Sqrt(a*a*sn*sn+b*b*cn*cn)
+		if !(next > lo && next < hi) {
+			next = lo + (hi-lo)/2
+		}
+
+		if math.Abs(next-theta) <= 0x1p-53*theta {
+			return next, f, true
+		}
+		theta = next
+	}
+
+	return theta, f, false
+}
+
+// EllipseArcIterator walks the ellipse x = a·cos t, y = b·sin t from t = 0
+// to t = 2π in steps of equal arc length. It keeps only the current
+// position, so the memory it needs does not depend on the number of
+// points. Use NewEllipseArcIterator to create one.
+type EllipseArcIterator struct {
+	a, b float64
+	step float64
+	n, i int
+}
+
+// NewEllipseArcIterator returns an iterator over the ellipse with semi
+// axes a and b. The perimeter P is split into n = round(P/spacing)
+// segments of length P/n, at least one, so the spacing is adjusted by
+// less than half a segment to make the last segment close the ellipse.
+// The iterator then yields the n+1 angles of the segment ends from 0 to
+// 2π. a, b and spacing must be positive and the spacing must not split
+// the perimeter into more than 2³⁰ segments, otherwise the iterator
+// yields nothing.
+func NewEllipseArcIterator(a, b, spacing float64) *EllipseArcIterator {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsNaN(spacing) || a <= 0 || b <= 0 || spacing <= 0 || math.IsInf(a, 1) || math.IsInf(b, 1) {
+		return &EllipseArcIterator{}
+	}
+
+	// The count is checked as a float64, since converting a ratio beyond
+	// the range of int, or +Inf, is undefined.
+	p := 4 * ellipseArc(a, b, 1, 0)
+	r := math.Round(p / spacing)
+	if !(r <= ellipseArcMaxSegments) {
+		return &EllipseArcIterator{}
+	}
+	n := int(math.Max(1, r))
+
+	it := EllipseArcIterator{
+		a:    a,
+		b:    b,
+		step: p / float64(n),
+		n:    n,
+	}
+
+	return &it
+}
+
+// Next returns the angle of the next point along the ellipse and reports
+// whether there was one. The first angle is 0 and the last one is exactly
+// 2π. After that, and for an iterator created with invalid arguments, it
+// returns NaN and false.
+func (it *EllipseArcIterator) Next() (theta float64, ok bool) {
+
+	// An iterator without segments was created with invalid arguments.
+	if it.n == 0 || it.i > it.n {
+		return math.NaN(), false
+	}
+
+	i := it.i
+	it.i++
+
+	switch i {
+	case 0:
+		return 0, true
+	case it.n:
+		return 2 * math.Pi, true
+	}
+
+	return EllipseAngleForArcLength(it.a, it.b, float64(i)*it.step), true
+}
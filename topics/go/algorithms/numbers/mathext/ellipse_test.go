@@ -0,0 +1,326 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestEllipseArcLength(t *testing.T) {
+	tt := []struct {
+		name     string
+		a, b, th float64
+		want     float64
+	}{
+		{"wide", 3, 2, 1, 2.3036429919396886},
+		{"tall", 1, 2, 0.5, 0.9694426748534585},
+		{"flat", 5, 0.1, 1.2, 3.1929929379223942},
+		{"circle", 1, 1, 0.7, 0.7},
+		{"perimeter", 3, 2, 2 * math.Pi, 15.86543958929059},
+		{"negative", 3, 2, -1, -2.3036429919396886},
+	}
+
+	t.Log("Given the need to measure arcs along an ellipse.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking a=%v b=%v θ=%v.", testID, test.a, test.b, test.th)
+				{
+					got := mathext.EllipseArcLength(test.a, test.b, test.th)
+					if e := relErr(got, test.want); e > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : rel err %g.", failed, testID, test.want, got, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestEllipseArcLengthQuarter(t *testing.T) {
+	t.Log("Given the need to end a quarter arc exactly at the b axis.")
+	{
+		t.Logf("\tTest 0:\tWhen θ is the float64 closest to ±π/2.")
+		{
+			for _, ab := range [][2]float64{{3, 2}, {1, 2}, {5, 0.1}, {1, 1e-12}, {1, 1 - 1e-9}} {
+				a, b := ab[0], ab[1]
+				q := mathext.EllipsePerimeter(a, b) / 4
+				if got := mathext.EllipseArcLength(a, b, math.Pi/2); got != q {
+					t.Fatalf("\t%s\tTest 0:\tShould get the quarter perimeter %v for a=%v b=%v, got %v.", failed, q, a, b, got)
+				}
+				if got := mathext.EllipseArcLength(a, b, -math.Pi/2); got != -q {
+					t.Fatalf("\t%s\tTest 0:\tShould get %v at -π/2 for a=%v b=%v, got %v.", failed, -q, a, b, got)
+				}
+				if got := mathext.EllipseAngleForArcLength(a, b, q); got != math.Pi/2 {
+					t.Fatalf("\t%s\tTest 0:\tShould map the quarter perimeter back to π/2 for a=%v b=%v, got %v.", failed, a, b, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get ±P/4 and π/2 back.", succeed)
+		}
+	}
+}
+
+func TestEllipseArcLengthTurns(t *testing.T) {
+	t.Log("Given the need to measure arcs over many turns.")
+	{
+		t.Logf("\tTest 0:\tWhen θ is far from the a axis.")
+		{
+
+			// For b > a the arc is b·E(θ|1 - a²/b²), and both reduce θ by
+			// the same multiple of π.
+			for _, th := range []float64{-7.5, 1000.25, 12345.678, 1e6, -3e7 + 0.1, 1e10, 1e15} {
+				got, want := mathext.EllipseArcLength(1, 2, th), 2*mathext.EllipticE(th, 0.75)
+				if got != want {
+					t.Fatalf("\t%s\tTest 0:\tShould get 2·E(θ|3/4) = %v at θ=%v, got %v.", failed, want, th, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match EllipticE.", succeed)
+		}
+	}
+}
+
+func TestEllipsePerimeter(t *testing.T) {
+	tt := []struct {
+		name string
+		a, b float64
+		want float64
+	}{
+		{"ratio2", 1, 0.5, 4.844224110273838},
+		{"nearCircle", 3, 2.9999999, 18.849555607379497},
+		{"needle", 1, 1e-8, 4.0000000000000036},
+		{"thin", 2.5, 0.1, 10.03285828266842},
+		{"huge", 1e300, 1e299, 4.063974180100896e300},
+	}
+
+	t.Log("Given the need to compute the perimeter of an ellipse.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with high precision values.")
+		{
+			for _, test := range tt {
+				for _, ab := range [][2]float64{{test.a, test.b}, {test.b, test.a}} {
+					if got := mathext.EllipsePerimeter(ab[0], ab[1]); math.Abs(got-test.want) > 2*ulp(test.want) {
+						t.Fatalf("\t%s\tTest 0:\tShould get %v for %s, got %v.", failed, test.want, test.name, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match to two ulp in either order.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen the ellipse is a circle, a segment or invalid.")
+		{
+			for _, a := range []float64{1, 0.3, 7} {
+				if got := mathext.EllipsePerimeter(a, a); relErr(got, 2*math.Pi*a) > 2e-16 {
+					t.Fatalf("\t%s\tTest 1:\tShould get 2πa for a=%v, got %v.", failed, a, got)
+				}
+				if got := mathext.EllipsePerimeter(a, 0); got != 4*a {
+					t.Fatalf("\t%s\tTest 1:\tShould get 4a for a=%v, got %v.", failed, a, got)
+				}
+				if got, want := mathext.EllipsePerimeter(a, a/3), 2*mathext.EllipseArcLength(a, a/3, math.Pi); got != want {
+					t.Fatalf("\t%s\tTest 1:\tShould match EllipseArcLength over a half turn, got %v and %v.", failed, got, want)
+				}
+			}
+			if mathext.EllipsePerimeter(0, 0) != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 0 for a point.", failed)
+			}
+			for _, ab := range [][2]float64{{-1, 1}, {1, math.Inf(1)}, {math.NaN(), 1}} {
+				if got := mathext.EllipsePerimeter(ab[0], ab[1]); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest 1:\tShould get NaN for a=%v, b=%v, got %v.", failed, ab[0], ab[1], got)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get 2πa, 4a, 0 and NaN.", succeed)
+		}
+	}
+}
+
+func TestEllipseArcLengthNeedle(t *testing.T) {
+
+	// Quarter perimeters of the ellipse with a = 1 and b = r, computed as
+	// 2·RG(0, r², 1) in 60 digit arithmetic.
+	quarter := []struct {
+		r    float64
+		want float64
+	}{
+		{1e-1, 1.015993545025224},
+		{1e-2, 1.000274582430663},
+		{1e-3, 1.000003897026172},
+		{1e-4, 1.0000000504831739},
+		{1e-5, 1.000000000619961},
+		{1e-6, 1.000000000007351},
+		{1e-7, 1.000000000000085},
+		{1e-8, 1.0000000000000009},
+		{1e-9, 1},
+		{1e-10, 1},
+		{1e-11, 1},
+		{1e-12, 1},
+		{0, 1},
+	}
+
+	t.Log("Given the need to measure needle-thin ellipses.")
+	{
+		t.Logf("\tTest 0:\tWhen sweeping b/a down to 1e-12 at several scales.")
+		{
+			for _, test := range quarter {
+				for _, a := range []float64{1, 1e300, 1e-300, 0x1p-1000} {
+					for _, flip := range []bool{false, true} {
+						x, y := a, a*test.r
+						if flip {
+							x, y = y, x
+						}
+						p := mathext.EllipseArcLength(x, y, 2*math.Pi) / a
+						if d := math.Abs(p - 4*test.want); d > 2*ulp(4*test.want) {
+							t.Fatalf("\t%s\tTest 0:\tShould get the perimeter %v·%v for b/a=%v, got %v : off by %v ulp.", failed, 4*test.want, a, test.r, p*a, d/ulp(4*test.want))
+						}
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get the perimeter to a few ulp.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen measuring arcs short of a quarter.")
+		{
+			arcs := []struct {
+				r, th, want float64
+			}{
+				{1e-4, 0.3, 0.04466355668634851},
+				{1e-4, 1.2, 0.6376422959203022},
+				{1e-8, 0.3, 0.04466351087439489},
+				{1e-8, 1.2, 0.6376422455233274},
+				{1e-12, 0.3, 0.04466351087439398},
+				{1e-12, 1.2, 0.6376422455233264},
+			}
+			for _, test := range arcs {
+				if got := mathext.EllipseArcLength(1, test.r, test.th); math.Abs(got-test.want) > 2*ulp(test.want) {
+					t.Fatalf("\t%s\tTest 1:\tShould get %v for b/a=%v at θ=%v, got %v.", failed, test.want, test.r, test.th, got)
+				}
+			}
+			if got, want := mathext.EllipseArcLength(2, 0, 1), 2*(1-math.Cos(1)); math.Abs(got-want) > 2*ulp(want) {
+				t.Fatalf("\t%s\tTest 1:\tShould get the segment length %v for b=0, got %v.", failed, want, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get the arcs to a few ulp.", succeed)
+		}
+	}
+}
+
+func TestEllipseAngleForArcLength(t *testing.T) {
+	axes := []struct {
+		name string
+		a, b float64
+	}{
+		{"wide", 3, 2},
+		{"tall", 1, 2},
+		{"flat", 5, 0.1},
+		{"circle", 1, 1},
+	}
+
+	t.Log("Given the need to split an ellipse into arcs of equal length.")
+	{
+		for testID, test := range axes {
+			tf := func(t *testing.T) {
+				p := mathext.EllipseArcLength(test.a, test.b, 2*math.Pi)
+
+				t.Logf("\tTest %d:\tWhen round-tripping through EllipseArcLength for a=%v b=%v.", testID, test.a, test.b)
+				{
+					for i := 0; i < 64; i++ {
+						th := 2 * math.Pi * (float64(i) + 0.3) / 64
+						s := mathext.EllipseArcLength(test.a, test.b, th)
+						got := mathext.EllipseAngleForArcLength(test.a, test.b, s)
+						if d := math.Abs(got - th); d > 1e-14 {
+							t.Fatalf("\t%s\tTest %d:\tShould get θ=%v back, got %v.", failed, testID, th, got)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould get the angle back.", succeed, testID)
+				}
+
+				t.Logf("\tTest %d:\tWhen checking the ends of the quadrants.", testID)
+				{
+					if got := mathext.EllipseAngleForArcLength(test.a, test.b, 0); got != 0 {
+						t.Fatalf("\t%s\tTest %d:\tShould get 0 at s=0, got %v.", failed, testID, got)
+					}
+					q := mathext.EllipseArcLength(test.a, test.b, math.Pi/2)
+					if got := mathext.EllipseAngleForArcLength(test.a, test.b, q); got != math.Pi/2 {
+						t.Fatalf("\t%s\tTest %d:\tShould get π/2 at a quarter perimeter, got %v.", failed, testID, got)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get 0 and π/2 exactly.", succeed, testID)
+				}
+
+				t.Logf("\tTest %d:\tWhen wrapping lengths outside of one turn.", testID)
+				{
+					s := 0.4 * p
+					want := mathext.EllipseAngleForArcLength(test.a, test.b, s)
+					for _, w := range []float64{s - p, s + p, s + 3*p} {
+						got := mathext.EllipseAngleForArcLength(test.a, test.b, w)
+						if d := math.Abs(got - want); d > 1e-13 {
+							t.Fatalf("\t%s\tTest %d:\tShould get %v for s=%v, got %v.", failed, testID, want, w, got)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould wrap into [0, 2π).", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestEllipseArcIterator(t *testing.T) {
+	tt := []struct {
+		name       string
+		a, b, step float64
+	}{
+		{"flat", 3, 1, 0.1},
+		{"tall", 0.5, 2, 0.37},
+		{"circle", 1, 1, 0.2},
+		{"coarse", 2, 1, 100},
+	}
+
+	t.Log("Given the need to walk an ellipse in steps of equal arc length.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen walking a=%v, b=%v with spacing %v.", testID, test.a, test.b, test.step)
+				{
+					p := 4 * mathext.EllipseArcLength(test.a, test.b, math.Pi/2)
+					n := int(math.Max(1, math.Round(p/test.step)))
+					seg := p / float64(n)
+
+					it := mathext.NewEllipseArcIterator(test.a, test.b, test.step)
+					var angles []float64
+					for theta, ok := it.Next(); ok; theta, ok = it.Next() {
+						angles = append(angles, theta)
+					}
+
+					if len(angles) != n+1 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %d points, got %d.", failed, testID, n+1, len(angles))
+					}
+					if angles[0] != 0 || angles[n] != 2*math.Pi {
+						t.Fatalf("\t%s\tTest %d:\tShould start at 0 and close at 2π, got %v and %v.", failed, testID, angles[0], angles[n])
+					}
+					for i := 1; i < len(angles); i++ {
+						d := mathext.EllipseArcLength(test.a, test.b, angles[i]) - mathext.EllipseArcLength(test.a, test.b, angles[i-1])
+						if math.Abs(d-seg) > 1e-13*p {
+							t.Fatalf("\t%s\tTest %d:\tShould get segments of %v, got %v at %d.", failed, testID, seg, d, i)
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %d equal segments of %v.", succeed, testID, n, seg)
+
+					if theta, ok := it.Next(); ok || !math.IsNaN(theta) {
+						t.Fatalf("\t%s\tTest %d:\tShould be exhausted, got %v %v.", failed, testID, theta, ok)
+					}
+					t.Logf("\t%s\tTest %d:\tShould be exhausted after 2π.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen the arguments are invalid.", len(tt))
+		{
+			for _, args := range [][3]float64{{0, 1, 0.1}, {1, 1, 0}, {1, -1, 0.1}, {1, 1, math.NaN()}, {1, 1, 0x1p-1074}, {1, 1, 1e-300}, {math.MaxFloat64, 1, 1}} {
+				if _, ok := mathext.NewEllipseArcIterator(args[0], args[1], args[2]).Next(); ok {
+					t.Fatalf("\t%s\tTest %d:\tShould yield nothing for %v.", failed, len(tt), args)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould yield nothing.", succeed, len(tt))
+		}
+	}
+}
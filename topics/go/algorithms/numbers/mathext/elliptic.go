@@ -0,0 +1,189 @@
+package mathext
+
+import "math"
+
+// Elliptic holds everything that depends only on the parameter m, so that
+// many incomplete integrals, Jacobi functions and theta functions at the
+// same m share one setup. It caches K(m), K(1-m), E(m), the nome and the
+// Landen transformation behind the Jacobi functions. Every method returns
+// the same value as the free function it mirrors. An Elliptic is not
+// modified after NewElliptic and is safe for concurrent use.
+type Elliptic struct {
+	m, mc   float64
+	k, kc   float64
+	e       float64
+	q       float64
+	descent *descent
+	nearOne *nearOne
+}
+
+// NewElliptic prepares the evaluation of the elliptic functions at the
+// parameter m. m must be in [0, 1]; outside of it every method returns
+// NaN.
+func NewElliptic(m float64) *Elliptic {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		nan := math.NaN()
+		return &Elliptic{m: nan, mc: nan, k: nan, kc: nan, e: nan, q: nan}
+	}
+
+	mc := 1 - m
+	el := Elliptic{
+		m:  m,
+		mc: mc,
+		k:  completeK(m, mc),
+		kc: completeK(mc, m),
+		e:  completeE(m, mc),
+		q:  Nome(m),
+	}
+
+	// At m = 1 the Jacobi functions are hyperbolic and need no descent.
+	if mc != 0 {
+		el.descent = newDescent(m, mc)
+		if mc < nearOneMc {
+			el.nearOne = newNearOne(mc)
+		}
+	}
+
+	return &el
+}
+
+// M returns the parameter m.
+func (el *Elliptic) M() float64 {
+	return el.m
+}
+
+// K returns the complete integral of the first kind K(m) like CompleteK.
+func (el *Elliptic) K() float64 {
+	return el.k
+}
+
+// KPrime returns the complementary integral K'(m) = K(1-m).
+func (el *Elliptic) KPrime() float64 {
+	return el.kc
+}
+
+// CompleteE returns the complete integral of the second kind E(m) like
+// CompleteE.
+func (el *Elliptic) CompleteE() float64 {
+	return el.e
+}
+
+// Nome returns the nome q(m) like Nome.
+func (el *Elliptic) Nome() float64 {
+	return el.q
+}
+
+// F computes the incomplete integral of the first kind F(φ|m) like
+// EllipticF.
+func (el *Elliptic) F(phi float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(el.m) {
+		return math.NaN()
+	}
+
+	// At m = 0 the integrand is 1.
+	if el.m == 0 {
+		return phi
+	}
+
+	f, k := ellipticFReduced(phi, el.mc)
+	if k != 0 {
+		f += 2 * k * el.k
+	}
+
+	return f
+}
+
+// E computes the incomplete integral of the second kind E(φ|m) like
+// EllipticE.
+func (el *Elliptic) E(phi float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(el.m) {
+		return math.NaN()
+	}
+
+	// At m = 0 the integrand is 1.
+	if el.m == 0 {
+		return phi
+	}
+
+	e, k := ellipticEReduced(phi, el.m, el.mc)
+	if k != 0 {
+		e += 2 * k * el.e
+	}
+
+	return e
+}
+
+// Jacobi computes sn(u|m), cn(u|m) and dn(u|m) like Jacobi.
+func (el *Elliptic) Jacobi(u float64) (sn, cn, dn float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(u) || math.IsNaN(el.m) {
+		nan := math.NaN()
+		return nan, nan, nan
+	}
+
+	// At m = 1 the functions degenerate to hyperbolic functions and at
+	// m = 0 to circular ones.
+	if el.descent == nil {
+		sech := 1 / math.Cosh(u)
+		return math.Tanh(u), sech, sech
+	}
+	if el.m == 0 {
+		sn, cn = math.Sincos(u)
+		return sn, cn, 1
+	}
+
+	sn, cn, dn, _ = jacobiAt(el.descent, el.nearOne, u)
+	return sn, cn, dn
+}
+
+// Amplitude computes am(u|m) like JacobiAmplitude.
+func (el *Elliptic) Amplitude(u float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(u) || math.IsNaN(el.m) {
+		return math.NaN()
+	}
+
+	// At m = 1 the amplitude is the Gudermannian function and at m = 0 it
+	// is u.
+	if el.descent == nil {
+		return math.Atan(math.Sinh(u))
+	}
+	if el.m == 0 {
+		return u
+	}
+
+	phi, _ := el.descent.amplitude(u)
+	return phi
+}
+
+// Theta1 computes θ₁(z, q) at the nome of m like Theta1(z, Nome(m)).
+func (el *Elliptic) Theta1(z float64) float64 {
+	v, _ := theta(1, z, el.q)
+	return v
+}
+
+// Theta2 computes θ₂(z, q) at the nome of m like Theta2(z, Nome(m)).
+func (el *Elliptic) Theta2(z float64) float64 {
+	v, _ := theta(2, z, el.q)
+	return v
+}
+
+// Theta3 computes θ₃(z, q) at the nome of m like Theta3(z, Nome(m)).
+func (el *Elliptic) Theta3(z float64) float64 {
+	v, _ := theta(3, z, el.q)
+	return v
+}
+
+// Theta4 computes θ₄(z, q) at the nome of m like Theta4(z, Nome(m)).
+func (el *Elliptic) Theta4(z float64) float64 {
+	v, _ := theta(4, z, el.q)
+	return v
+}
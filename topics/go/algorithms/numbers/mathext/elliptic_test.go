@@ -0,0 +1,96 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+// same reports whether a and b are the same value, treating NaN as equal
+// to itself.
+func same(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
+
+func TestElliptic(t *testing.T) {
+	t.Log("Given the need to reuse the setup for one parameter.")
+	{
+		for testID, m := range []float64{0, 0.3, 0.5, 0.9, 0.999, 1 - 1e-9, 1} {
+			t.Logf("\tTest %d:\tWhen checking m=%v.", testID, m)
+			{
+				el := mathext.NewElliptic(m)
+				q := mathext.Nome(m)
+				if el.M() != m || !same(el.K(), mathext.CompleteK(m)) || !same(el.KPrime(), mathext.CompleteK(1-m)) || !same(el.CompleteE(), mathext.CompleteE(m)) || !same(el.Nome(), q) {
+					t.Fatalf("\t%s\tTest %d:\tShould cache m, K, K', E and q: got %v %v %v %v %v.", failed, testID, el.M(), el.K(), el.KPrime(), el.CompleteE(), el.Nome())
+				}
+
+				for x := -7.0; x <= 7; x += 0.125 {
+					if f, e := el.F(x), el.E(x); !same(f, mathext.EllipticF(x, m)) || !same(e, mathext.EllipticE(x, m)) {
+						t.Fatalf("\t%s\tTest %d:\tShould match EllipticF and EllipticE at φ=%v: got %v %v.", failed, testID, x, f, e)
+					}
+
+					sn, cn, dn := el.Jacobi(x)
+					s, c, d := mathext.Jacobi(x, m)
+					if sn != s || cn != c || dn != d || el.Amplitude(x) != mathext.JacobiAmplitude(x, m) {
+						t.Fatalf("\t%s\tTest %d:\tShould match Jacobi and JacobiAmplitude at u=%v: got %v %v %v, want %v %v %v.", failed, testID, x, sn, cn, dn, s, c, d)
+					}
+
+					if !same(el.Theta1(x), mathext.Theta1(x, q)) || !same(el.Theta2(x), mathext.Theta2(x, q)) || !same(el.Theta3(x), mathext.Theta3(x, q)) || !same(el.Theta4(x), mathext.Theta4(x, q)) {
+						t.Fatalf("\t%s\tTest %d:\tShould match the theta functions at z=%v.", failed, testID, x)
+					}
+				}
+
+				// The ends of the first half period and far out angles go
+				// through the half period reduction of F and E. Where the
+				// compiler fuses multiply-adds it may round the method and
+				// the free function apart, so they are allowed an ulp.
+				for _, x := range []float64{math.Pi / 2, -math.Pi / 2, 3 * math.Pi / 2, -5 * math.Pi / 2, 1e5} {
+					f, e := el.F(x), el.E(x)
+					wf, we := mathext.EllipticF(x, m), mathext.EllipticE(x, m)
+					if !same(f, wf) && !(math.Abs(f-wf) <= ulp(wf)) || !same(e, we) && !(math.Abs(e-we) <= ulp(we)) {
+						t.Fatalf("\t%s\tTest %d:\tShould match EllipticF and EllipticE at φ=%v: got %v %v.", failed, testID, x, f, e)
+					}
+				}
+				t.Logf("\t%s\tTest %d:\tShould match the free functions.", succeed, testID)
+			}
+		}
+
+		t.Logf("\tTest 7:\tWhen checking arguments outside of the domain.")
+		{
+			for _, m := range []float64{-0.1, 1.1, math.NaN()} {
+				el := mathext.NewElliptic(m)
+				sn, _, _ := el.Jacobi(0.5)
+				if !math.IsNaN(el.K()) || !math.IsNaN(el.F(0)) || !math.IsNaN(el.E(0)) || !math.IsNaN(sn) || !math.IsNaN(el.Amplitude(0)) || !math.IsNaN(el.Theta3(0)) {
+					t.Fatalf("\t%s\tTest 7:\tShould get NaN at m=%v.", failed, m)
+				}
+			}
+			el := mathext.NewElliptic(0.5)
+			if !math.IsNaN(el.F(math.Inf(1))) || !math.IsNaN(el.E(math.NaN())) {
+				t.Fatalf("\t%s\tTest 7:\tShould reject an infinite or NaN φ.", failed)
+			}
+			t.Logf("\t%s\tTest 7:\tShould return NaN.", succeed)
+		}
+	}
+}
+
+func BenchmarkEllipticStruct(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		el := mathext.NewElliptic(0.7)
+		for j := 0; j < 16; j++ {
+			x := 0.1 * float64(j)
+			sn, cn, dn = el.Jacobi(x)
+			k = el.F(x) + el.E(x) + el.Theta3(x)
+		}
+	}
+}
+
+func BenchmarkEllipticFree(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 16; j++ {
+			x := 0.1 * float64(j)
+			sn, cn, dn = mathext.Jacobi(x, 0.7)
+			k = mathext.EllipticF(x, 0.7) + mathext.EllipticE(x, 0.7) + mathext.Theta3(x, mathext.Nome(0.7))
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package mathext
+
+import (
+	"fmt"
+	"math"
+)
+
+// EllipticRational computes the elliptic rational function Rₙ(ξ, x) of
+// degree n and selectivity factor ξ, the characteristic function of a
+// Cauer (elliptic) filter. It is the rational function with
+//
+//	|Rₙ(ξ, x)| <= 1           for |x| <= 1
+//	|Rₙ(ξ, x)| >= Lₙ          for |x| >= ξ
+//	Rₙ(ξ, 1) = 1,  Rₙ(ξ, ξ/x) = Lₙ/Rₙ(ξ, x)
+//
+// where Lₙ = Rₙ(ξ, ξ) is the discrimination factor. It is built from its
+// zeros and poles
+//
+//	Rₙ(ξ, x) = r₀ Π (x - xᵢ)/(x - ξ/xᵢ),  xᵢ = cd((2i - 1)K/n | 1/ξ²)
+//
+// with K = K(1/ξ²), cd = cn/dn and r₀ chosen so that Rₙ(ξ, 1) = 1. For odd
+// n the middle zero is at 0 and its pole at infinity. n must be positive
+// and ξ greater than 1.
+func EllipticRational(n int, xi, x float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if n < 1 || math.IsNaN(xi) || math.IsNaN(x) || xi <= 1 || math.IsInf(xi, 1) {
+		return math.NaN()
+	}
+
+	// The parameter of the functions is 1/ξ² and its complement
+	// (ξ - 1)(ξ + 1)/ξ² keeps its precision as ξ approaches 1.
+	m := 1 / (xi * xi)
+	mc := (xi - 1) * (xi + 1) / (xi * xi)
+	k := completeK(m, mc)
+
+	// Every zero and its pole contribute (x - z)/(x - p), normalized by
+	// the same factor at x = 1. The factors approach 1 for infinite x.
+	r := 1.0
+	for i := 1; i <= n; i++ {
+		if 2*i-1 == n {
+			r *= x
+			continue
+		}
+
+		_, cn, dn := jacobiFuncs(k*float64(2*i-1)/float64(n), m, mc)
+		z := cn / dn
+		p := xi / z
+		if !math.IsInf(x, 0) {
+			r *= (x - z) / (x - p)
+		}
+		r /= (1 - z) / (1 - p)
+	}
+
+	return r
+}
+
+// EllipticFilterOrder computes the smallest degree n of an elliptic
+// (Cauer) low-pass filter with at most passbandRipple dB of attenuation up
+// to the pass band edge ωp and at least stopbandRipple dB from the stop
+// band edge ωs = selectivity·ωp on. With
+//
+//	εp² = 10^(Ap/10) - 1,  εs² = 10^(As/10) - 1
+//	k = 1/ξ,  k₁ = εp/εs
+//
+// the degree follows from the degree equation as the smallest integer
+//
+//	n >= K(k)·K'(k₁) / (K'(k)·K(k₁))
+//
+// with K' the complementary integral and both ratios taken through the
+// nome. The ratio r is lowered by a relative 2⁻⁴⁰ before rounding up, so
+// a specification taken from a filter of degree n, whose ratio is n up to
+// rounding errors, gives n. A true ratio n + ε with ε < r·2⁻⁴⁰ is then
+// rounded down to n as well, and the filter of degree n falls short of
+// the stop band ripple by a correspondingly small amount.
+//
+// The ripples must satisfy 0 < Ap < As and the selectivity must be finite
+// and greater than 1, otherwise 0 is returned. 0 is also returned when the
+// stop band ripple is so large that no finite degree reaches it. Use
+// EllipticFilterOrderErr to tell these cases apart.
+func EllipticFilterOrder(passbandRipple, stopbandRipple, selectivity float64) int {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(passbandRipple) || math.IsNaN(stopbandRipple) || passbandRipple <= 0 ||
+		stopbandRipple <= passbandRipple || math.IsInf(stopbandRipple, 1) ||
+		math.IsNaN(selectivity) || selectivity <= 1 || math.IsInf(selectivity, 1) {
+		return 0
+	}
+
+	n, _ := ellipticFilterOrder(passbandRipple, stopbandRipple, selectivity)
+	return n
+}
+
+// EllipticFilterOrderErr computes EllipticFilterOrder and returns an error
+// for arguments outside of the domain or when no finite degree reaches the
+// stop band ripple, in which case the degree is 0.
+func EllipticFilterOrderErr(passbandRipple, stopbandRipple, selectivity float64) (int, error) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(passbandRipple) || math.IsNaN(stopbandRipple) || passbandRipple <= 0 ||
+		stopbandRipple <= passbandRipple || math.IsInf(stopbandRipple, 1) {
+		return 0, fmt.Errorf("mathext: EllipticFilterOrder needs 0 < Ap < As < +Inf, got Ap=%v As=%v", passbandRipple, stopbandRipple)
+	}
+	if math.IsNaN(selectivity) || selectivity <= 1 || math.IsInf(selectivity, 1) {
+		return 0, fmt.Errorf("mathext: EllipticFilterOrder needs a finite selectivity > 1, got %v", selectivity)
+	}
+
+	n, ok := ellipticFilterOrder(passbandRipple, stopbandRipple, selectivity)
+	if !ok {
+		return 0, fmt.Errorf("mathext: EllipticFilterOrder needs an infinite degree for As=%v", stopbandRipple)
+	}
+
+	return n, nil
+}
+
+// ellipticFilterOrder solves the degree equation for valid arguments. It
+// also returns whether a finite degree reaches the stop band ripple.
+func ellipticFilterOrder(ap, as, xi float64) (int, bool) {
+	ep := math.Expm1(ap * math.Ln10 / 10)
+	es := math.Expm1(as * math.Ln10 / 10)
+	m1, mc1 := ep/es, (es-ep)/es
+
+	// A stop band beyond the range of float64 needs an infinite degree.
+	if m1 == 0 {
+		return 0, false
+	}
+
+	m, mc := 1/(xi*xi), (xi-1)*(xi+1)/(xi*xi)
+
+	// A specification taken from a filter of degree n gives n within the
+	// rounding errors of the two ratios, so those do not round it up.
+	r := periodRatio(m1, mc1) / periodRatio(m, mc)
+	return int(math.Ceil(r * (1 - 0x1p-40))), true
+}
+
+// EllipticModulusFromOrder solves the degree equation of elliptic filters
+//
+//	K'(k₁)/K(k₁) = n·K'(k)/K(k)
+//
+// for the discrimination modulus k₁ of the filter of degree n and
+// selectivity modulus k = 1/ξ. In terms of the nomes it reads q(k₁²) =
+// q(k²)ⁿ, so k₁ follows from the nome by ParameterFromPeriods. The
+// filter reaches Lₙ = 1/k₁ = Rₙ(ξ, ξ), so it meets the ripples εp and εs
+// of EllipticFilterOrder when k₁ <= εp/εs. n must be positive and k in
+// [0, 1].
+func EllipticModulusFromOrder(n int, k float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if n < 1 || math.IsNaN(k) || k < 0 || k > 1 {
+		return math.NaN()
+	}
+
+	if n == 1 || k == 0 || k == 1 {
+		return k
+	}
+
+	m1, _ := ParameterFromPeriods(1, float64(n)*periodRatio(k*k, (1-k)*(1+k)))
+	return math.Sqrt(m1)
+}
@@ -0,0 +1,203 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestEllipticRational(t *testing.T) {
+	t.Log("Given the need to design elliptic filters.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the pass band and the stop band.")
+		{
+			for n := 1; n <= 6; n++ {
+				for _, xi := range []float64{1.05, 1.5, 3} {
+					l := mathext.EllipticRational(n, xi, xi)
+					lo, hi := 0.0, 0.0
+					for x := -1.0; x <= 1; x += 1.0 / 1024 {
+						r := mathext.EllipticRational(n, xi, x)
+						lo, hi = math.Min(lo, r), math.Max(hi, r)
+					}
+					if hi > 1+1e-12 || lo < -1-1e-12 || hi < 1 || n > 1 && lo > -1+1e-3 {
+						t.Fatalf("\t%s\tTest 0:\tShould ripple between -1 and 1 for n=%d, ξ=%v: got [%v, %v].", failed, n, xi, lo, hi)
+					}
+					for x := xi; x <= 16*xi; x += xi / 64 {
+						if r := mathext.EllipticRational(n, xi, x); math.Abs(r) < l*(1-1e-12) {
+							t.Fatalf("\t%s\tTest 0:\tShould stay above Lₙ=%v for n=%d, ξ=%v at x=%v: got %v.", failed, l, n, xi, x, r)
+						}
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould stay within ±1 for |x| <= 1 and above Lₙ for x >= ξ.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the closed forms of the first two degrees.")
+		{
+			for _, xi := range []float64{1.01, 1.2, 2, 10} {
+				tc := math.Sqrt(1 - 1/(xi*xi))
+				for x := -3.0; x <= 3; x += 0.125 {
+					if r := mathext.EllipticRational(1, xi, x); r != x {
+						t.Fatalf("\t%s\tTest 1:\tShould get R₁(ξ, x) = x at ξ=%v, x=%v: got %v.", failed, xi, x, r)
+					}
+					want := ((tc+1)*x*x - 1) / ((tc-1)*x*x + 1)
+					if r := mathext.EllipticRational(2, xi, x); relErr(r, want) > 1e-13 {
+						t.Fatalf("\t%s\tTest 1:\tShould get R₂(ξ=%v, x=%v) = %v, got %v.", failed, xi, x, want, r)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould match R₁ = x and the closed form of R₂.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking the reciprocal symmetry and the nesting property.")
+		{
+			for _, xi := range []float64{1.1, 1.5, 4} {
+				for n := 1; n <= 5; n++ {
+					l := mathext.EllipticRational(n, xi, xi)
+					for _, x := range []float64{-2.5, -0.7, 0.3, 0.9, 1.7, 3.5} {
+						r := mathext.EllipticRational(n, xi, x)
+						if got := mathext.EllipticRational(n, xi, xi/x) * r; relErr(got, l) > 1e-11 {
+							t.Fatalf("\t%s\tTest 2:\tShould get Rₙ(ξ, ξ/x)·Rₙ(ξ, x) = Lₙ for n=%d, ξ=%v, x=%v: got %v, want %v.", failed, n, xi, x, got, l)
+						}
+						for m := 2; m <= 3; m++ {
+							want := mathext.EllipticRational(m*n, xi, x)
+							if got := mathext.EllipticRational(m, l, r); relErr(got, want) > 1e-9 {
+								t.Fatalf("\t%s\tTest 2:\tShould get Rₘ(Lₙ, Rₙ(ξ, x)) = Rₘₙ(ξ, x) for m=%d, n=%d, ξ=%v, x=%v: got %v, want %v.", failed, m, n, xi, x, got, want)
+							}
+						}
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould satisfy both.", succeed)
+		}
+
+		t.Logf("\tTest 3:\tWhen checking infinite x and arguments outside of the domain.")
+		{
+			big := mathext.EllipticRational(3, 1.5, -1e300)
+			if r := mathext.EllipticRational(3, 1.5, math.Inf(-1)); !math.IsInf(r, 0) || math.Signbit(r) != math.Signbit(big) {
+				t.Fatalf("\t%s\tTest 3:\tShould get an infinity of the sign of %v for an odd degree, got %v.", failed, big, r)
+			}
+			want := mathext.EllipticRational(2, 1.5, 1.5) * mathext.EllipticRational(2, 1.5, 0)
+			if r := mathext.EllipticRational(2, 1.5, math.Inf(1)); relErr(r, want) > 1e-13 {
+				t.Fatalf("\t%s\tTest 3:\tShould get Lₙ·Rₙ(ξ, 0) for an even degree, got %v, want %v.", failed, r, want)
+			}
+			for _, a := range [][3]float64{{0, 1.5, 0.5}, {2, 1, 0.5}, {2, 0.5, 0.5}, {2, math.Inf(1), 0.5}, {2, math.NaN(), 0.5}, {2, 1.5, math.NaN()}} {
+				if r := mathext.EllipticRational(int(a[0]), a[1], a[2]); !math.IsNaN(r) {
+					t.Fatalf("\t%s\tTest 3:\tShould get NaN at n=%v, ξ=%v, x=%v, got %v.", failed, a[0], a[1], a[2], r)
+				}
+			}
+			t.Logf("\t%s\tTest 3:\tShould get the limits and NaN.", succeed)
+		}
+	}
+}
+
+func TestEllipticFilterOrder(t *testing.T) {
+	t.Log("Given the need to size an elliptic filter.")
+	{
+		t.Logf("\tTest 0:\tWhen designing the lowpass filter of the MATLAB ellipord example.")
+		{
+
+			// 3 dB up to 40 Hz and 60 dB from 150 Hz on at 1000 Hz, with the
+			// band edges prewarped by the bilinear transform, needs a degree
+			// of 3.0004 and so a fourth order filter.
+			xi := math.Tan(math.Pi*0.15) / math.Tan(math.Pi*0.04)
+			if n := mathext.EllipticFilterOrder(3, 60, xi); n != 4 {
+				t.Fatalf("\t%s\tTest 0:\tShould get a degree of 4, got %d.", failed, n)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get a degree of 4.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking other specifications.")
+		{
+			tt := []struct {
+				ap, as, xi float64
+				n          int
+			}{
+				{0.5, 40, 1.5, 5},
+				{1, 50, 1.2, 6},
+				{0.1, 80, 1.05, 13},
+				{0.1, 60, 10, 3},
+			}
+			for _, test := range tt {
+				if n, err := mathext.EllipticFilterOrderErr(test.ap, test.as, test.xi); err != nil || n != test.n {
+					t.Fatalf("\t%s\tTest 1:\tShould get a degree of %d for %v dB, %v dB and ξ=%v, got %d : %v.", failed, test.n, test.ap, test.as, test.xi, n, err)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get the degrees.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen solving the degree equation.")
+		{
+			tt := []struct {
+				n       int
+				k, want float64
+			}{
+				{3, 0.5, 0.00963737037258032},
+				{5, 0.8, 0.004066026488201042},
+				{2, 0.99, 0.7527449039962069},
+			}
+			for _, test := range tt {
+				if k1 := mathext.EllipticModulusFromOrder(test.n, test.k); relErr(k1, test.want) > 1e-14 {
+					t.Fatalf("\t%s\tTest 2:\tShould get k₁=%v for n=%d, k=%v, got %v.", failed, test.want, test.n, test.k, k1)
+				}
+			}
+
+			for n := 1; n <= 6; n++ {
+				for _, xi := range []float64{1.05, 1.5, 3} {
+					k1 := mathext.EllipticModulusFromOrder(n, 1/xi)
+					if l := mathext.EllipticRational(n, xi, xi); relErr(1/k1, l) > 1e-11 {
+						t.Fatalf("\t%s\tTest 2:\tShould get 1/k₁ = Lₙ for n=%d, ξ=%v, got %v, want %v.", failed, n, xi, 1/k1, l)
+					}
+
+					// A specification met exactly by degree n gives n back.
+					const ap = 1.0
+					ep := math.Expm1(ap * math.Ln10 / 10)
+					as := 10 * math.Log10(1+ep/(k1*k1))
+					if got := mathext.EllipticFilterOrder(ap, as, xi); got != n {
+						t.Fatalf("\t%s\tTest 2:\tShould get back the degree %d for ξ=%v, got %d.", failed, n, xi, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get k₁ with 1/k₁ = Rₙ(ξ, ξ).", succeed)
+		}
+
+		t.Logf("\tTest 3:\tWhen the specification sits at an integer degree.")
+		{
+
+			// A degree of 4 at ξ = 1.5 reaches As = 10·log₁₀(1 + εp²/k₁²). A
+			// stop band ripple 1e-9 dB higher needs a degree of 4 plus 7e-11,
+			// beyond the margin of 4·2⁻⁴⁰ below which it still rounds to 4.
+			const ap, xi = 1.0, 1.5
+			k1 := mathext.EllipticModulusFromOrder(4, 1/xi)
+			ep := math.Expm1(ap * math.Ln10 / 10)
+			as := 10 * math.Log10(1+ep/(k1*k1))
+			if n := mathext.EllipticFilterOrder(ap, as, xi); n != 4 {
+				t.Fatalf("\t%s\tTest 3:\tShould get a degree of 4 at As=%v, got %d.", failed, as, n)
+			}
+			if n := mathext.EllipticFilterOrder(ap, as+1e-9, xi); n != 5 {
+				t.Fatalf("\t%s\tTest 3:\tShould get a degree of 5 just above As=%v, got %d.", failed, as, n)
+			}
+			t.Logf("\t%s\tTest 3:\tShould round up only beyond the margin.", succeed)
+		}
+
+		t.Logf("\tTest 4:\tWhen checking arguments outside of the domain.")
+		{
+			for _, a := range [][3]float64{{0, 40, 2}, {3, 3, 2}, {1, math.Inf(1), 2}, {1, 4000, 2}, {1, 40, 1}, {1, 40, math.Inf(1)}, {math.NaN(), 40, 2}} {
+				if n, err := mathext.EllipticFilterOrderErr(a[0], a[1], a[2]); err == nil || n != 0 {
+					t.Fatalf("\t%s\tTest 4:\tShould get an error for %v, got %d.", failed, a, n)
+				}
+				if n := mathext.EllipticFilterOrder(a[0], a[1], a[2]); n != 0 {
+					t.Fatalf("\t%s\tTest 4:\tShould get 0 for %v, got %d.", failed, a, n)
+				}
+			}
+			if !math.IsNaN(mathext.EllipticModulusFromOrder(0, 0.5)) || !math.IsNaN(mathext.EllipticModulusFromOrder(2, 1.5)) || !math.IsNaN(mathext.EllipticModulusFromOrder(2, math.NaN())) {
+				t.Fatalf("\t%s\tTest 4:\tShould get NaN from EllipticModulusFromOrder.", failed)
+			}
+			if mathext.EllipticModulusFromOrder(3, 0) != 0 || mathext.EllipticModulusFromOrder(3, 1) != 1 {
+				t.Fatalf("\t%s\tTest 4:\tShould keep k = 0 and k = 1.", failed)
+			}
+			t.Logf("\t%s\tTest 4:\tShould reject the arguments.", succeed)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+//go:build go1.18
+
+package mathext
+
+// Float is a constraint that permits any floating-point type. It has the
+// same type set as constraints.Float from golang.org/x/exp.
+type Float interface {
+	~float32 | ~float64
+}
+
+// CompleteKGeneric computes CompleteK for any floating-point type. The
+// integral is always evaluated in float64 and the result is converted back
+// to T, so float32 callers get the correctly rounded float64 answer.
+func CompleteKGeneric[T Float](m T) T {
+	return T(CompleteK(float64(m)))
+}
+
+// CompleteEGeneric computes CompleteE for any floating-point type. The
+// integral is always evaluated in float64 and the result is converted back
+// to T.
+func CompleteEGeneric[T Float](m T) T {
+	return T(CompleteE(float64(m)))
+}
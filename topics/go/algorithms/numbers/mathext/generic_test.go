@@ -0,0 +1,55 @@
+//go:build go1.18
+
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestCompleteGeneric(t *testing.T) {
+	t.Log("Given the need to call the complete integrals with any float type.")
+	{
+		t.Logf("\tTest 0:\tWhen using float64.")
+		{
+			for _, m := range []float64{0, 0.25, 0.5, 0.99, 1} {
+				if got, want := mathext.CompleteKGeneric(m), mathext.CompleteK(m); got != want {
+					t.Fatalf("\t%s\tTest 0:\tShould get K(%v)=%v, got %v.", failed, m, want, got)
+				}
+				if got, want := mathext.CompleteEGeneric(m), mathext.CompleteE(m); got != want {
+					t.Fatalf("\t%s\tTest 0:\tShould get E(%v)=%v, got %v.", failed, m, want, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match the float64 functions.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen using float32.")
+		{
+			for _, m := range []float32{0, 0.25, 0.5, 0.99, 1} {
+				if got, want := mathext.CompleteKGeneric(m), float32(mathext.CompleteK(float64(m))); got != want {
+					t.Fatalf("\t%s\tTest 1:\tShould get K(%v)=%v, got %v.", failed, m, want, got)
+				}
+				if got, want := mathext.CompleteEGeneric(m), float32(mathext.CompleteE(float64(m))); got != want {
+					t.Fatalf("\t%s\tTest 1:\tShould get E(%v)=%v, got %v.", failed, m, want, got)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould match the rounded float64 functions.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen crossing the domain boundary.")
+		{
+			if k := mathext.CompleteKGeneric(float32(1)); !math.IsInf(float64(k), 1) {
+				t.Fatalf("\t%s\tTest 2:\tShould get +Inf for K(1), got %v.", failed, k)
+			}
+			for _, m := range []float32{-0.5, 1.5, float32(math.NaN())} {
+				k, e := mathext.CompleteKGeneric(m), mathext.CompleteEGeneric(m)
+				if !math.IsNaN(float64(k)) || !math.IsNaN(float64(e)) {
+					t.Fatalf("\t%s\tTest 2:\tShould get NaN for m=%v, got %v and %v.", failed, m, k, e)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get NaN outside of [0, 1].", succeed)
+		}
+	}
+}
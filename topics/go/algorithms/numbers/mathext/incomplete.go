@@ -0,0 +1,427 @@
+package mathext
+
+import "math"
+
+// EllipticF computes the incomplete elliptic integral of the first kind.
+//
+//	F(φ|m) = ∫₀^φ dθ / √(1 - m sin²θ)
+//
+// m must be in [0, 1]. The integral is odd in φ and quasi-periodic with
+// F(φ + kπ|m) = F(φ|m) + 2k·K(m). At m = 1 it diverges once |φ| reaches
+// π/2 and ±Inf is returned with the sign of φ.
+func EllipticF(phi, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	return ellipticF(phi, m, 1-m)
+}
+
+// ellipticF evaluates F(φ|m) given both m and its complement mc = 1 - m.
+func ellipticF(phi, m, mc float64) float64 {
+
+	// At m = 0 the integrand is 1.
+	if m == 0 {
+		return phi
+	}
+
+	f, k := ellipticFReduced(phi, mc)
+	if k != 0 {
+		f += 2 * k * completeK(m, mc)
+	}
+
+	return f
+}
+
+// ellipticFReduced reduces φ to [-π/2, π/2] and returns F(φ|m) there
+// together with the number k of half periods that were removed, so that
+// the full integral is f + 2k·K(m).
+func ellipticFReduced(phi, mc float64) (f, k float64) {
+	phi, k = reduceHalfPeriods(phi)
+
+	// At m = 1 the integral diverges at π/2, which float64(π/2) stands
+	// for, although it falls short of π/2 by 6e-17. Beyond it the half
+	// periods carry the divergence.
+	if mc == 0 && k == 0 && math.Abs(phi) == math.Pi/2 {
+		return math.Copysign(math.Inf(1), phi), k
+	}
+
+	s, c := math.Sincos(phi)
+
+	// F(φ|m) = sin φ·RF(cos²φ, Δ², 1) where Δ² = 1 - m sin²φ is formed
+	// without cancellation.
+	c2 := c * c
+	if s != 0 {
+		f = s * rf(c2, c2+mc*s*s, 1)
+	}
+
+	return f, k
+}
+
+// piLo is π - float64(π), the part of π that does not fit in a float64.
+const piLo = 2 * piOver2Lo
+
+// reduceHalfPeriods splits φ into k·π + r with an integer k and r in
+// [-π/2, π/2] up to rounding. k·π is removed in two parts with fused
+// multiply-adds, so r is the distance of φ to the nearest multiple of π
+// itself and not to the rounded k·π, whose error grows with k.
+func reduceHalfPeriods(phi float64) (r, k float64) {
+
+	// float64(π/2) lies below π/2 but φ/π rounds to exactly 1/2 there,
+	// which would move it to the far end of the interval. Next to m = 1,
+	// where F and E are steep at π/2, that lands on the wrong side.
+	if math.Abs(phi) <= math.Pi/2 {
+		return phi, 0
+	}
+
+	k = math.Round(phi / math.Pi)
+	r = math.FMA(-k, math.Pi, phi)
+
+	return math.FMA(-k, piLo, r), k
+}
+
+// EllipticFInverse computes the amplitude φ for which EllipticF(φ, m) = f,
+// which is the Jacobi amplitude am(f|m) stated for the integral. f is
+// first split into whole periods with the quasi-periodicity of F,
+//
+//	f = 2k·K(m) + r,  φ = k·π + am(r|m)
+//
+// so the amplitude of a value many periods out is found from r in
+// [-K, K], where the Landen transformation keeps its precision. m must be
+// in [0, 1]. At m = 1 the integral covers (-π/2, π/2) once and φ is the
+// Gudermannian function of f, at m = 0 it is f.
+func EllipticFInverse(f, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	switch m {
+	case 0:
+		return f
+	case 1:
+		return math.Atan(math.Sinh(f))
+	}
+
+	mc := 1 - m
+	d := newDescent(m, mc)
+	kk := completeK(m, mc)
+	k := math.Round(f / (2 * kk))
+	phi, _ := d.amplitude(math.FMA(-2*k, kk, f))
+
+	return k*math.Pi + phi
+}
+
+// EllipticE computes the incomplete elliptic integral of the second kind.
+//
+//	E(φ|m) = ∫₀^φ √(1 - m sin²θ) dθ
+//
+// m must be in [0, 1]. The integral is odd in φ and quasi-periodic with
+// E(φ + kπ|m) = E(φ|m) + 2k·E(m).
+func EllipticE(phi, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	return ellipticE(phi, m, 1-m)
+}
+
+// ellipticE evaluates E(φ|m) given both m and its complement mc = 1 - m.
+func ellipticE(phi, m, mc float64) float64 {
+
+	// At m = 0 the integrand is 1.
+	if m == 0 {
+		return phi
+	}
+
+	e, k := ellipticEReduced(phi, m, mc)
+	if k != 0 {
+		e += 2 * k * completeE(m, mc)
+	}
+
+	return e
+}
+
+// ellipticEReduced reduces φ to [-π/2, π/2] and returns E(φ|m) there
+// together with the number k of half periods that were removed, so that
+// the full integral is e + 2k·E(m).
+func ellipticEReduced(phi, m, mc float64) (e, k float64) {
+	phi, k = reduceHalfPeriods(phi)
+
+	s, c := math.Sincos(phi)
+
+	// At m = 1 the integrand is cos θ.
+	if mc == 0 {
+		return s, k
+	}
+
+	// float64(π/2) stands for π/2, where the integral is complete. It
+	// falls short by 6e-17, which moves E by less than that since the
+	// integrand is at most 1, so E(m) is returned and both agree exactly.
+	if k == 0 && math.Abs(phi) == math.Pi/2 {
+		return math.Copysign(completeE(m, mc), phi), k
+	}
+
+	return ellipticESinCos(s, c, m, mc), k
+}
+
+// ellipticESinCos evaluates E(φ|m) for φ in [-π/2, π/2] from sin φ and
+// cos φ with mc > 0. The usual form
+//
+//	E(φ|m) = sin φ·RF(cos²φ, Δ², 1) - m/3·sin³φ·RD(cos²φ, Δ², 1)
+//
+// subtracts two terms that grow like K(m) as m approaches 1 and φ
+// approaches π/2, while E stays close to 1. Its rearrangement (DLMF 19.25.10)
+//
+//	E(φ|m) = mc·F(φ|m) + m·mc/3·sin³φ·RD(cos²φ, 1, Δ²) + m sin φ cos φ/Δ
+//
+// adds terms of the same sign, so E(±π/2|m) agrees with ±E(m) and the
+// error stays within a few ulp next to m = 1. Δ² = 1 - m sin²φ is formed
+// without cancellation.
+func ellipticESinCos(s, c, m, mc float64) float64 {
+	c2, s2 := c*c, s*s
+	d2 := c2 + mc*s2
+	return mc*s*rf(c2, d2, 1) + m*mc/3*s*s2*rd(c2, 1, d2) + m*s*c/math.Sqrt(d2)
+}
+
+// EllipticFSin computes F(φ|m) for φ in [-π/2, π/2] from s = sin φ.
+//
+//	F(φ|m) = s·RF(1 - s², 1 - m s², 1)
+//
+// Callers that already hold sin φ skip the trigonometric round trip, and
+// cos²φ is formed as (1 - s)(1 + s), which keeps its relative precision
+// as |s| approaches 1 where recomputing it from φ would not. s must be in
+// [-1, 1] and m in [0, 1]. At m = 1 and |s| = 1 the integral diverges and
+// ±Inf is returned with the sign of s.
+func EllipticFSin(sinPhi, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(sinPhi) || math.IsNaN(m) || sinPhi < -1 || sinPhi > 1 || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	if sinPhi == 0 {
+		return sinPhi
+	}
+
+	// At m = 0 the integrand is 1.
+	if m == 0 {
+		return math.Asin(sinPhi)
+	}
+
+	s2 := sinPhi * sinPhi
+	c2 := (1 - sinPhi) * (1 + sinPhi)
+	return sinPhi * rf(c2, c2+(1-m)*s2, 1)
+}
+
+// EllipticESin computes E(φ|m) for φ in [-π/2, π/2] from s = sin φ.
+//
+//	E(φ|m) = s·RF(1 - s², 1 - m s², 1) - m/3·s³·RD(1 - s², 1 - m s², 1)
+//
+// It is the counterpart of EllipticFSin. s must be in [-1, 1] and m in
+// [0, 1].
+func EllipticESin(sinPhi, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(sinPhi) || math.IsNaN(m) || sinPhi < -1 || sinPhi > 1 || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	// At m = 1 the integrand is cos θ and at m = 0 it is 1.
+	if sinPhi == 0 || m == 1 {
+		return sinPhi
+	}
+	if m == 0 {
+		return math.Asin(sinPhi)
+	}
+
+	// At sin φ = ±1 the integral is complete.
+	if sinPhi == 1 || sinPhi == -1 {
+		return math.Copysign(completeE(m, 1-m), sinPhi)
+	}
+
+	c2 := (1 - sinPhi) * (1 + sinPhi)
+	return ellipticESinCos(sinPhi, math.Sqrt(c2), m, 1-m)
+}
+
+// EllipticFAngle computes F(φ\α), the incomplete elliptic integral of the
+// first kind in the modular angle convention of Abramowitz and Stegun,
+// where m = sin²α. α must be in [0, π/2]. The complement 1 - m is taken as
+// cos²α so that it keeps its precision as α approaches π/2, and α = π/2
+// itself is treated as m = 1.
+func EllipticFAngle(phi, alpha float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(alpha) || alpha < 0 || alpha > math.Pi/2 {
+		return math.NaN()
+	}
+
+	m, mc := modularAngle(alpha)
+	return ellipticF(phi, m, mc)
+}
+
+// EllipticEAngle computes E(φ\α), the incomplete elliptic integral of the
+// second kind in the modular angle convention of Abramowitz and Stegun,
+// where m = sin²α. α must be in [0, π/2] and is handled as in
+// EllipticFAngle.
+func EllipticEAngle(phi, alpha float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(alpha) || alpha < 0 || alpha > math.Pi/2 {
+		return math.NaN()
+	}
+
+	m, mc := modularAngle(alpha)
+	return ellipticE(phi, m, mc)
+}
+
+// modularAngle converts the modular angle α to the parameter m = sin²α and
+// its complement cos²α. The float64 closest to π/2 maps to m = 1.
+func modularAngle(alpha float64) (m, mc float64) {
+	if alpha == math.Pi/2 {
+		return 1, 0
+	}
+
+	s, c := math.Sincos(alpha)
+	return s * s, c * c
+}
+
+// EllipticFModulus computes F(φ, k), the incomplete elliptic integral of
+// the first kind in the modulus convention where m = k². The complement
+// is formed as in CompleteKModulus. k must be in [-1, 1].
+func EllipticFModulus(phi, k float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(k) || k < -1 || k > 1 {
+		return math.NaN()
+	}
+
+	m, mc := modulus(k)
+	return ellipticF(phi, m, mc)
+}
+
+// EllipticEModulus computes E(φ, k), the incomplete elliptic integral of
+// the second kind in the modulus convention where m = k². k must be in
+// [-1, 1].
+func EllipticEModulus(phi, k float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(k) || k < -1 || k > 1 {
+		return math.NaN()
+	}
+
+	m, mc := modulus(k)
+	return ellipticE(phi, m, mc)
+}
+
+// modulus converts the modulus k to the parameter m = k² and its
+// complement (1 - k)(1 + k).
+func modulus(k float64) (m, mc float64) {
+	return k * k, (1 - k) * (1 + k)
+}
+
+// EllipticPi computes the incomplete elliptic integral of the third kind.
+//
+//	Π(n; φ|m) = ∫₀^φ dθ / ((1 - n sin²θ) √(1 - m sin²θ))
+//
+// m must be in [0, 1]. The integral is odd in φ and quasi-periodic with
+// Π(n; φ + kπ|m) = Π(n; φ|m) + 2k·Π(n|m). When n sin²φ > 1 the pole lies
+// inside the interval and the Cauchy principal value is returned. When
+// n sin²φ = 1 the pole sits on the upper limit, the integral diverges and
+// ±Inf is returned with the sign of φ. For n <= 1 the distance to the pole
+// 1 - n sin²φ is formed as (1 - n) + n cos²φ, so the result keeps its
+// precision as n and φ approach 1 and π/2 together.
+//
+// The characteristic falls into the hyperbolic cases 0 < n < m and n > 1
+// and the circular cases m < n < 1 and n < 0 of Abramowitz and Stegun
+// 17.7. Inside [0, 1] the Carlson form is used directly. Outside of it
+// the form cancels, and the changes of characteristic of DLMF §19.7(iii)
+// that CompletePi uses move n into (0, 1), with the extra term of the
+// incomplete integral written as an arctangent for n < 0 and as RC for
+// n > 1. Beyond the pole the principal value can be much smaller than
+// F(φ|m), and its error is then a few ulp of F(φ|m) rather than of the
+// result. At m = 0 the integral is elementary, an arctangent for n < 1,
+// an inverse hyperbolic tangent for n > 1 and tan φ for n = 1.
+func EllipticPi(n, phi, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(n) || math.IsNaN(phi) || math.IsInf(phi, 0) || math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	// Reduce φ to [-π/2, π/2] and remember how many half periods were
+	// removed. φ = ±π/2 is left alone: the rounded π/2 is below π/2, so the
+	// shift would land on the other side of the pole.
+	var k float64
+	if math.Abs(phi) > math.Pi/2 {
+		phi, k = reduceHalfPeriods(phi)
+	}
+	s, c := math.Sincos(phi)
+
+	// Π(n; φ|m) = sin φ·RF(cos²φ, Δ², 1) + n/3·sin³φ·RJ(cos²φ, Δ², 1, 1 - n sin²φ)
+	// where Δ² = 1 - m sin²φ is formed without cancellation. For n <= 1
+	// the same is done for 1 - n sin²φ = (1 - n) + n cos²φ, which is tiny
+	// close to the pole and carries the divergence of the integral, and
+	// for n > 1 it is cos²φ - (n - 1) sin²φ, which only cancels at the
+	// pole itself.
+	c2, s2 := c*c, s*s
+	d2 := c2 + (1-m)*s2
+	p := c2 - (n-1)*s2
+	if n <= 1 {
+		p = (1 - n) + n*c2
+	}
+
+	var pi float64
+	switch {
+	case p == 0:
+		pi = math.Copysign(math.Inf(1), s)
+	case s == 0 || math.IsInf(n, 0):
+		pi = 0
+	case m == 0:
+		// The integral is elementary in t = tan φ, with the principal
+		// value taken through the reciprocal beyond the pole.
+		t := math.Tan(phi)
+		switch {
+		case n < 1:
+			a := math.Sqrt(1 - n)
+			pi = math.Atan(a*t) / a
+		case n > 1:
+			a := math.Sqrt(n - 1)
+			if x := a * t; math.Abs(x) < 1 {
+				pi = math.Atanh(x) / a
+			} else {
+				pi = math.Atanh(1/x) / a
+			}
+		default:
+			pi = t
+		}
+	case n > 1:
+		// Beyond the pole the principal value is small next to F, so the
+		// characteristic is moved to m/n with DLMF 19.7.8, where F
+		// cancels analytically and RC carries the pole.
+		q := c2 + (n-m)/n*s2
+		pi = -m/n/3*s*s2*rj(c2, d2, 1, q) + s*rc(c2*d2, p*q)
+	case n < 0:
+		// The RJ term cancels most of F as n goes to -Inf, so move to
+		// N = (m - n)/(1 - n) in (m, 1) with DLMF 19.7.9, where every
+		// term is positive.
+		a, nn := -n/(1-n), (m-n)/(1-n)
+		f := s * rf(c2, d2, 1)
+		pin := f + nn/3*s*s2*rj(c2, d2, 1, (1-m)/(1-n)+nn*c2)
+		pi = a*(1-m)/(m-n)*pin + m/(m-n)*f +
+			math.Sqrt(a/(m-n))*math.Atan(math.Sqrt(a*(m-n))*s*c/math.Sqrt(d2))
+	default:
+		pi = s*rf(c2, d2, 1) + n/3*s*s2*rj(c2, d2, 1, p)
+	}
+
+	if k != 0 {
+		pi += 2 * k * CompletePi(n, m)
+	}
+
+	return pi
+}
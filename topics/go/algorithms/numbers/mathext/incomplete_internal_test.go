@@ -0,0 +1,35 @@
+package mathext
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReduceHalfPeriods(t *testing.T) {
+	tt := []struct {
+		phi, r, k float64
+	}{
+		{1e5, -0.035756416708573505, 31831},
+		{100000.5, 0.4642435832914265, 31831},
+		{12345.678, -0.7811286078875436, 3930},
+		{10000*math.Pi + math.Pi/3, 1.0471975511968286, 10000},
+		{-1e5, 0.035756416708573505, -31831},
+		{1, 1, 0},
+		{math.Pi / 2, math.Pi / 2, 0},
+		{-math.Pi / 2, -math.Pi / 2, 0},
+	}
+
+	t.Log("Given the need to remove whole half periods from an angle.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with the exact remainders.")
+		{
+			for _, test := range tt {
+				r, k := reduceHalfPeriods(test.phi)
+				if k != test.k || math.Abs(r-test.r) > math.Abs(math.Nextafter(test.r, 0)-test.r) {
+					t.Fatalf("\t✗\tTest 0:\tShould get %v·π + %v for φ=%v, got %v·π + %v.", test.k, test.r, test.phi, k, r)
+				}
+			}
+			t.Logf("\t✓\tTest 0:\tShould get the remainders to an ulp.")
+		}
+	}
+}
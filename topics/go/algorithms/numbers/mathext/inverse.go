@@ -0,0 +1,211 @@
+package mathext
+
+import (
+	"fmt"
+	"math"
+)
+
+// inverseMaxIter bounds the Newton iteration of CompleteKInverse. The
+// iteration converges quadratically from the asymptotic starting point, so
+// a handful of steps is the norm.
+const inverseMaxIter = 32
+
+// CompleteKInverse computes the parameter m in [0, 1] for which
+// CompleteK(m) = k. k must be at least π/2 = K(0) and +Inf maps to m = 1.
+//
+// The equation is solved with Newton's method in t = -ln(1-m)/2. In this
+// variable K grows like t + ln 4 for large t, its derivative dK/dt is the
+// integral B(m), which lies between π/4 and 1, and K is convex, so the
+// iteration started from t = k - ln 4 converges monotonically and keeps
+// full precision in 1 - m as m approaches 1.
+func CompleteKInverse(k float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(k) || k < math.Pi/2 {
+		return math.NaN()
+	}
+
+	m, _, _ := completeKInverse(k, k-2*math.Ln2, inverseMaxIter)
+	return m
+}
+
+// completeKInverse solves K(m) = k by Newton's method in t starting from
+// the guess t0 with at most maxIter steps. It also returns the residual
+// K(m) - k of the last step and whether the step size met the tolerance.
+func completeKInverse(k, t0 float64, maxIter int) (float64, float64, bool) {
+	switch {
+	case k == math.Pi/2:
+		return 0, 0, true
+	case math.IsInf(k, 1):
+		return 1, 0, true
+	}
+
+	residual, ok := 0.0, false
+	t := math.Max(t0, 0)
+	for i := 0; i < maxIter; i++ {
+		mc := math.Exp(-2 * t)
+		m := -math.Expm1(-2 * t)
+
+		residual = completeK(m, mc) - k
+		if residual == 0 {
+			ok = true
+			break
+		}
+
+		// dK/dt = 2(1-m)·dK/dm = B(m).
+		dt := residual / completeB(mc)
+		t = math.Max(t-dt, 0)
+		if math.Abs(dt) <= 0x1p-53*t {
+			ok = true
+			break
+		}
+	}
+
+	return -math.Expm1(-2 * t), residual, ok
+}
+
+// CompleteKInverseErr computes CompleteKInverse and returns an error for k
+// outside of the domain or when Newton's method did not meet its
+// tolerance within inverseMaxIter steps, in which case m is the last
+// iterate and the error names the residual K(m) - k.
+func CompleteKInverseErr(k float64) (m float64, err error) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(k) || k < math.Pi/2 {
+		return math.NaN(), fmt.Errorf("mathext: CompleteKInverse needs k >= π/2, got %v", k)
+	}
+
+	m, r, ok := completeKInverse(k, k-2*math.Ln2, inverseMaxIter)
+	if !ok {
+		return m, fmt.Errorf("mathext: CompleteKInverse did not converge at k=%v: residual %g", k, r)
+	}
+
+	return m, nil
+}
+
+// CompleteKInverseSlice computes CompleteKInverse for every element of k
+// and stores the results in dst, which is allocated when nil. Each
+// element after the first starts Newton's method from the solution of the
+// previous one, which saves iterations when k is sorted or varies slowly
+// and costs little otherwise. CompleteKInverseSlice panics if dst is not
+// nil and has a different length than k.
+func CompleteKInverseSlice(dst, k []float64) []float64 {
+	if dst == nil {
+		dst = make([]float64, len(k))
+	}
+	if len(dst) != len(k) {
+		panic("mathext: slice length mismatch")
+	}
+
+	// Carry the Newton variable t of the last valid solution.
+	t, warm := 0.0, false
+	for i, v := range k {
+		if math.IsNaN(v) || v < math.Pi/2 {
+			dst[i] = math.NaN()
+			continue
+		}
+
+		t0 := v - 2*math.Ln2
+		if warm {
+			t0 = t
+		}
+		dst[i], _, _ = completeKInverse(v, t0, inverseMaxIter)
+
+		if dst[i] < 1 {
+			t, warm = -math.Log1p(-dst[i])/2, true
+		}
+	}
+
+	return dst
+}
+
+// SolveKEqualsCE computes the parameter m in [0, 1) for which
+// K(m) = c·E(m). The ratio K/E grows monotonically from 1 at m = 0 to +Inf
+// at m = 1, so there is one solution for every c > 1, and +Inf maps to
+// m = 1.
+//
+// Like CompleteKInverse the equation h = K - c·E = 0 is solved by Newton's
+// method in t = -ln(1-m)/2, where
+//
+//	dh/dt = 2(1-m)·(dK/dm - c·dE/dm) = B(m) + c·(1-m)·D(m)
+//
+// has no cancellation. The steps are kept inside a bracket of the root
+// and fall back to bisection when they leave it. Close to m = 1 the
+// solution has t ≈ c - ln 4, so for c beyond about 20 the result rounds to
+// 1.
+func SolveKEqualsCE(c float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(c) || c <= 1 {
+		return math.NaN()
+	}
+
+	m, _, _ := solveKEqualsCE(c, inverseMaxIter)
+	return m
+}
+
+// SolveKEqualsCEErr computes SolveKEqualsCE and returns an error for c
+// outside of the domain or when the iteration did not meet its tolerance
+// within inverseMaxIter steps, in which case m is the last iterate and the
+// error names the residual K(m) - c·E(m).
+func SolveKEqualsCEErr(c float64) (m float64, err error) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(c) || c <= 1 {
+		return math.NaN(), fmt.Errorf("mathext: SolveKEqualsCE needs c > 1, got %v", c)
+	}
+
+	m, r, ok := solveKEqualsCE(c, inverseMaxIter)
+	if !ok {
+		return m, fmt.Errorf("mathext: SolveKEqualsCE did not converge at c=%v: residual %g", c, r)
+	}
+
+	return m, nil
+}
+
+// solveKEqualsCE runs the bracketed Newton iteration of SolveKEqualsCE for
+// at most maxIter steps. It also returns the residual K(m) - c·E(m) of the
+// last step and whether the step size met the tolerance.
+func solveKEqualsCE(c float64, maxIter int) (float64, float64, bool) {
+	if math.IsInf(c, 1) {
+		return 1, 0, true
+	}
+
+	// K/E ≈ 1 + m/2 for small m and ≈ t + ln 4 for large t. K > t + ln 4
+	// and E <= π/2 bound t from above by c·π/2.
+	t := c - 1
+	if c >= 2 {
+		t = c - 2*math.Ln2
+	}
+	lo, hi := 0.0, c*math.Pi/2
+
+	residual, ok := 0.0, false
+	for i := 0; i < maxIter; i++ {
+		mc := math.Exp(-2 * t)
+		m := -math.Expm1(-2 * t)
+
+		residual = completeK(m, mc) - c*completeE(m, mc)
+		if residual == 0 {
+			ok = true
+			break
+		}
+		if residual < 0 {
+			lo = t
+		} else {
+			hi = t
+		}
+
+		next := t - residual/(completeB(mc)+c*mc*completeD(mc))
+		if next <= lo || next >= hi {
+			next = (lo + hi) / 2
+		}
+		dt := next - t
+		t = next
+		if math.Abs(dt) <= 0x1p-53*t {
+			ok = true
+			break
+		}
+	}
+
+	return -math.Expm1(-2 * t), residual, ok
+}
@@ -0,0 +1,39 @@
+package mathext
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInverseIterationCap(t *testing.T) {
+	t.Log("Given the need to report an iteration that runs out of steps.")
+	{
+		t.Logf("\tTest 0:\tWhen allowing a single step.")
+		{
+			if m, r, ok := completeKInverse(5, 0, 1); ok || r == 0 || m < 0 || m > 1 {
+				t.Fatalf("\t✗\tTest 0:\tShould not converge in one step from a poor start, got %v %v %v.", m, r, ok)
+			}
+			if m, r, ok := solveKEqualsCE(10, 1); ok || r == 0 || m < 0 || m > 1 {
+				t.Fatalf("\t✗\tTest 0:\tShould not solve K = 10E in one step, got %v %v %v.", m, r, ok)
+			}
+			if theta, r, ok := ellipseAngleForArcLength(10, 0.1, 5, 1); ok || r == 0 || math.IsNaN(theta) {
+				t.Fatalf("\t✗\tTest 0:\tShould not invert the arc length in one step, got %v %v %v.", theta, r, ok)
+			}
+			t.Logf("\t✓\tTest 0:\tShould report the residual and no convergence.")
+		}
+
+		t.Logf("\tTest 1:\tWhen allowing the usual number of steps.")
+		{
+			if _, _, ok := completeKInverse(5, 0, inverseMaxIter); !ok {
+				t.Fatalf("\t✗\tTest 1:\tShould converge from a poor start.")
+			}
+			if _, _, ok := solveKEqualsCE(10, inverseMaxIter); !ok {
+				t.Fatalf("\t✗\tTest 1:\tShould solve K = 10E.")
+			}
+			if _, _, ok := ellipseAngleForArcLength(10, 0.1, 5, ellipseMaxIter); !ok {
+				t.Fatalf("\t✗\tTest 1:\tShould invert the arc length.")
+			}
+			t.Logf("\t✓\tTest 1:\tShould converge.")
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+// invTol is the error in m that follows from two ulp of error in K(m),
+// plus an ulp of m itself.
+func invTol(m float64) float64 {
+	return 2*ulp(mathext.CompleteK(m))/mathext.CompleteKDiff(m) + ulp(m)
+}
+
+func TestCompleteKInverse(t *testing.T) {
+	t.Log("Given the need to recover m from K(m).")
+	{
+		t.Logf("\tTest 0:\tWhen round-tripping through CompleteK.")
+		{
+			for _, m := range []float64{1e-3, 0.1, 0.35, 0.5, 0.8, 0.9, 0.99, 0.999999, 1 - 0x1p-40} {
+				got := mathext.CompleteKInverse(mathext.CompleteK(m))
+				if d := math.Abs(got - m); d > invTol(m) {
+					t.Fatalf("\t%s\tTest 0:\tShould get m=%v back, got %v.", failed, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get m back.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the edges of the domain.")
+		{
+			if got := mathext.CompleteKInverse(math.Pi / 2); got != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 0 for π/2, got %v.", failed, got)
+			}
+			if got := mathext.CompleteKInverse(math.Inf(1)); got != 1 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 1 for +Inf, got %v.", failed, got)
+			}
+			for _, k := range []float64{1.5, -1, math.NaN()} {
+				if got := mathext.CompleteKInverse(k); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest 1:\tShould get NaN for k=%v, got %v.", failed, k, got)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould handle the edges.", succeed)
+		}
+	}
+}
+
+func TestCompleteKInverseSlice(t *testing.T) {
+	m := make([]float64, 500)
+	for i := range m {
+		m[i] = float64(i) / float64(len(m))
+	}
+
+	t.Log("Given the need to invert K for a batch of values.")
+	{
+		t.Logf("\tTest 0:\tWhen inverting sorted values.")
+		{
+			k := mathext.CompleteKSlice(nil, m)
+			got := mathext.CompleteKInverseSlice(nil, k)
+			for i := range k {
+				want := mathext.CompleteKInverse(k[i])
+				if d := math.Abs(got[i] - want); d > invTol(want) {
+					t.Fatalf("\t%s\tTest 0:\tShould match CompleteKInverse(%v)=%v, got %v.", failed, k[i], want, got[i])
+				}
+				if d := math.Abs(got[i] - m[i]); d > invTol(m[i]) {
+					t.Fatalf("\t%s\tTest 0:\tShould round-trip m=%v, got %v.", failed, m[i], got[i])
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match the scalar inverse and round-trip.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen inverting unsorted values with invalid entries.")
+		{
+			k := []float64{3, 1.6, math.NaN(), 10, 1, 2}
+			dst := make([]float64, len(k))
+			mathext.CompleteKInverseSlice(dst, k)
+			for i := range k {
+				want := mathext.CompleteKInverse(k[i])
+				if math.IsNaN(want) != math.IsNaN(dst[i]) || math.Abs(dst[i]-want) > invTol(want) {
+					t.Fatalf("\t%s\tTest 1:\tShould match CompleteKInverse(%v)=%v, got %v.", failed, k[i], want, dst[i])
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould match the scalar inverse.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen passing slices of different lengths.")
+		{
+			if msg := mustPanic(func() { mathext.CompleteKInverseSlice(make([]float64, 2), m) }); msg != "mathext: slice length mismatch" {
+				t.Fatalf("\t%s\tTest 2:\tShould panic from CompleteKInverseSlice, got %v.", failed, msg)
+			}
+			if msg := mustPanic(func() { mathext.CompleteKSlice(make([]float64, 2), m) }); msg != "mathext: slice length mismatch" {
+				t.Fatalf("\t%s\tTest 2:\tShould panic from CompleteKSlice, got %v.", failed, msg)
+			}
+			t.Logf("\t%s\tTest 2:\tShould panic.", succeed)
+		}
+	}
+}
+
+func TestSolveKEqualsCE(t *testing.T) {
+	t.Log("Given the need to solve K(m) = c·E(m).")
+	{
+		t.Logf("\tTest 0:\tWhen checking K/E at the solution.")
+		{
+			for _, c := range []float64{1 + 1e-6, 1.001, 1.1, 1.5, 2, 3, 5, 10, 15} {
+				m := mathext.SolveKEqualsCE(c)
+				k, e := mathext.CompleteK(m), mathext.CompleteE(m)
+
+				// The tolerance is a few ulp of the ratio plus the change of
+				// the ratio over an ulp of m.
+				slope := (mathext.CompleteKDiff(m)*e - k*mathext.CompleteEDiff(m)) / (e * e)
+				if d := math.Abs(k/e - c); m <= 0 || m >= 1 || d > 4*ulp(c)+slope*ulp(m) {
+					t.Fatalf("\t%s\tTest 0:\tShould get K/E = %v at m=%v, got %v.", failed, c, m, k/e)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get K/E = c.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the edges of the domain.")
+		{
+			if got := mathext.SolveKEqualsCE(math.Inf(1)); got != 1 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 1 for +Inf, got %v.", failed, got)
+			}
+			if got := mathext.SolveKEqualsCE(100); got != 1 {
+				t.Fatalf("\t%s\tTest 1:\tShould round to 1 for c=100, got %v.", failed, got)
+			}
+			for _, c := range []float64{1, 0.5, math.NaN()} {
+				if got := mathext.SolveKEqualsCE(c); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest 1:\tShould get NaN for c=%v, got %v.", failed, c, got)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould handle the edges.", succeed)
+		}
+	}
+}
+
+func TestInverseErr(t *testing.T) {
+	t.Log("Given the need to know whether an inverse converged.")
+	{
+		t.Logf("\tTest 0:\tWhen the iterations converge.")
+		{
+			for _, k := range []float64{math.Pi / 2, 1.6, 2, 5, 30} {
+				m, err := mathext.CompleteKInverseErr(k)
+				if err != nil || m != mathext.CompleteKInverse(k) {
+					t.Fatalf("\t%s\tTest 0:\tShould match CompleteKInverse(%v) with a nil error, got %v %v.", failed, k, m, err)
+				}
+			}
+			for _, c := range []float64{1.001, 2, 10} {
+				m, err := mathext.SolveKEqualsCEErr(c)
+				if err != nil || m != mathext.SolveKEqualsCE(c) {
+					t.Fatalf("\t%s\tTest 0:\tShould match SolveKEqualsCE(%v) with a nil error, got %v %v.", failed, c, m, err)
+				}
+			}
+			for _, s := range []float64{0, 1, 3.3, 7, -2} {
+				theta, err := mathext.EllipseAngleForArcLengthErr(3, 1, s)
+				if err != nil || theta != mathext.EllipseAngleForArcLength(3, 1, s) {
+					t.Fatalf("\t%s\tTest 0:\tShould match EllipseAngleForArcLength at s=%v with a nil error, got %v %v.", failed, s, theta, err)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould return the same values and no error.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen the arguments are outside of the domain.")
+		{
+			if m, err := mathext.CompleteKInverseErr(1); err == nil || !math.IsNaN(m) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN and an error for k=1, got %v %v.", failed, m, err)
+			}
+			if m, err := mathext.SolveKEqualsCEErr(0.5); err == nil || !math.IsNaN(m) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN and an error for c=0.5, got %v %v.", failed, m, err)
+			}
+			if theta, err := mathext.EllipseAngleForArcLengthErr(-1, 1, 1); err == nil || !math.IsNaN(theta) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN and an error for a=-1, got %v %v.", failed, theta, err)
+			}
+			t.Logf("\t%s\tTest 1:\tShould return errors.", succeed)
+		}
+	}
+}
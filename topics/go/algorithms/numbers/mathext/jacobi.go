@@ -0,0 +1,514 @@
+package mathext
+
+import (
+	"math"
+	"math/cmplx"
+	"strings"
+)
+
+// descentMax bounds the number of steps in the descending Landen
+// transformation. The transformation converges quadratically so this is
+// only reached for pathological input.
+const descentMax = 32
+
+// descent holds the constants of the descending Landen transformation for
+// a fixed parameter m. They only depend on m, so a descent can be reused
+// for every argument u.
+type descent struct {
+	n     int
+	mc    float64
+	ratio float64
+	a     [descentMax + 1]float64
+	c     [descentMax + 1]float64
+}
+
+// newDescent runs the arithmetic-geometric mean of 1 and √mc and records
+// the a and c sequences. m must be in [0, 1) and mc = 1 - m is passed in
+// so callers that know the complement exactly do not lose it.
+func newDescent(m, mc float64) *descent {
+	d := descent{
+		mc: mc,
+	}
+
+	// Run the arithmetic-geometric mean until c vanishes to working
+	// precision, collecting Σ 2ⁿcₙ² along the way.
+	b := math.Sqrt(d.mc)
+	d.a[0] = 1
+	d.c[0] = math.Sqrt(m)
+	sum, pow := m, 1.0
+	for d.n < descentMax && math.Abs(d.c[d.n]) > 0x1p-53*d.a[d.n] {
+		n := d.n + 1
+		d.a[n] = (d.a[n-1] + b) / 2
+		d.c[n] = (d.a[n-1] - b) / 2
+		b = math.Sqrt(d.a[n-1] * b)
+
+		pow *= 2
+		sum += pow * d.c[n] * d.c[n]
+		d.n = n
+	}
+
+	// The ratio E(m)/K(m) falls out of the same iteration.
+	d.ratio = 1 - sum/2
+
+	return &d
+}
+
+// amplitude computes am(u|m) by walking the Landen sequence back down.
+// It also returns Σ cₙ sin φₙ, which is the correction needed to build
+// the Jacobi epsilon function from the same angles.
+func (d *descent) amplitude(u float64) (phi, sum float64) {
+	phi = math.Ldexp(d.a[d.n]*u, d.n)
+	for n := d.n; n > 0; n-- {
+		s := math.Sin(phi)
+		sum += d.c[n] * s
+		phi = (phi + math.Asin(d.c[n]*s/d.a[n])) / 2
+	}
+
+	return phi, sum
+}
+
+// jacobi evaluates sn, cn and dn from the amplitude. dn is formed as
+// √(cn² + mc·sn²) which avoids the cancellation in √(1 - m·sn²).
+func (d *descent) jacobi(phi float64) (sn, cn, dn float64) {
+	sn, cn = math.Sincos(phi)
+	dn = math.Sqrt(cn*cn + d.mc*sn*sn)
+
+	return sn, cn, dn
+}
+
+// nearOneMc is the complement below which cn and dn are rebuilt with
+// nearOne once they have decayed under nearOneCn. There they behave like
+// sech u over the long stretch before K, and cos φ of an amplitude next to
+// π/2 only keeps their absolute error.
+const (
+	nearOneMc = 0x1p-4
+	nearOneCn = 0.5
+)
+
+// nearOne applies the descending Landen transformation to the functions
+// (DLMF 22.7.i). With k₁ = (1 - k')/(1 + k') and v = u/(1 + k₁),
+//
+//	sn(u|k) = (1 + k₁)·sn(v|k₁)/(1 + k₁·sn²(v|k₁))
+//	cn(u|k) = cn(v|k₁)·dn(v|k₁)/(1 + k₁·sn²(v|k₁))
+//
+// and the complement of k₁² is 4k'/(1 + k')², which grows like the square
+// root of mc. A few steps reach a parameter where the amplitude form is
+// accurate, and on the way back up cn and dn only pass through products
+// and quotients so they keep their relative precision.
+type nearOne struct {
+	n     int
+	scale float64
+	k     [descentMax]float64
+	mc    [descentMax]float64
+	base  *descent
+}
+
+// newNearOne walks the parameter down from mc until its complement is at
+// least nearOneMc. mc must be in (0, nearOneMc).
+func newNearOne(mc float64) *nearOne {
+	g := nearOne{scale: 1}
+	for mc < nearOneMc && g.n < descentMax {
+		kc := math.Sqrt(mc)
+		g.k[g.n] = (1 - kc) / (1 + kc)
+		g.mc[g.n] = mc
+		g.scale *= (1 + kc) / 2
+		mc = 4 * kc / ((1 + kc) * (1 + kc))
+		g.n++
+	}
+	g.base = newDescent(1-mc, mc)
+
+	return &g
+}
+
+// jacobi evaluates sn, cn and dn at u. It is meant for |cn| < nearOneCn,
+// where sn is closer to ±1 than cn is to 0 and √(1 - cn²) gives it with
+// less error than the transformation. Taking sn and dn from cn also keeps
+// sn² + cn² = 1 and m·sn² + dn² = 1 to rounding.
+func (g *nearOne) jacobi(u float64) (sn, cn, dn float64) {
+	phi, _ := g.base.amplitude(u * g.scale)
+	sn, cn, dn = g.base.jacobi(phi)
+	for i := g.n - 1; i >= 0; i-- {
+		den := 1 + g.k[i]*sn*sn
+		sn, cn = (1+g.k[i])*sn/den, cn*dn/den
+		dn = math.Sqrt(cn*cn + g.mc[i]*sn*sn)
+	}
+
+	sn = math.Copysign(math.Sqrt((1-cn)*(1+cn)), sn)
+	dn = math.Sqrt(cn*cn + g.mc[0]*sn*sn)
+
+	return sn, cn, dn
+}
+
+// Jacobi computes the Jacobi elliptic functions sn(u|m), cn(u|m) and
+// dn(u|m) using the descending Landen transformation. Close to m = 1, where
+// cn and dn decay like sech u, they keep their relative precision and the
+// identities sn² + cn² = 1 and m·sn² + dn² = 1 hold to a couple of ulp.
+// m must be in [0, 1].
+func Jacobi(u, m float64) (sn, cn, dn float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(u) || math.IsNaN(m) || m < 0 || m > 1 {
+		nan := math.NaN()
+		return nan, nan, nan
+	}
+
+	return jacobiFuncs(u, m, 1-m)
+}
+
+// jacobiFuncs evaluates sn(u|m), cn(u|m) and dn(u|m) given both m and its
+// complement mc = 1 - m.
+func jacobiFuncs(u, m, mc float64) (sn, cn, dn float64) {
+
+	// At m = 1 the functions degenerate to hyperbolic functions and the
+	// Landen transformation no longer converges. At m = 0 they are the
+	// circular functions.
+	if mc == 0 {
+		sech := 1 / math.Cosh(u)
+		return math.Tanh(u), sech, sech
+	}
+	if m == 0 {
+		sn, cn = math.Sincos(u)
+		return sn, cn, 1
+	}
+
+	sn, cn, dn, _ = jacobiAt(newDescent(m, mc), nil, u)
+	return sn, cn, dn
+}
+
+// jacobiAt evaluates sn, cn and dn at u from the descent d, or from g
+// where cn has decayed next to m = 1, and also returns the sum of the
+// amplitude. It lets callers that evaluate many arguments at one
+// parameter set both up once. A nil g is built here, only when it is
+// needed. g must be nil unless d.mc < nearOneMc.
+func jacobiAt(d *descent, g *nearOne, u float64) (sn, cn, dn, sum float64) {
+	var phi float64
+	phi, sum = d.amplitude(u)
+	sn, cn, dn = d.jacobi(phi)
+	if d.mc < nearOneMc && math.Abs(cn) < nearOneCn {
+		if g == nil {
+			g = newNearOne(d.mc)
+		}
+		sn, cn, dn = g.jacobi(u)
+	}
+
+	return sn, cn, dn, sum
+}
+
+// JacobiAmplitude computes the amplitude φ = am(u|m), the upper limit of
+// the integral F(φ|m) = u. The amplitude is not reduced to a single turn:
+// it grows by π over every 2K(m), am(u + 2K) = am(u) + π, so angles
+// built from it, like that of a rotating pendulum, keep the winding number
+// that atan2 of sn and cn would lose. m must be in [0, 1].
+func JacobiAmplitude(u, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(u) || math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	// At m = 1 the amplitude is the Gudermannian function and at m = 0 it
+	// is u.
+	if m == 1 {
+		return math.Atan(math.Sinh(u))
+	}
+	if m == 0 {
+		return u
+	}
+
+	phi, _ := newDescent(m, 1-m).amplitude(u)
+	return phi
+}
+
+// JacobiSNInverse computes arcsn(x|m), the u in [-K, K] with sn(u|m) = x.
+// It is the first-kind integral at the amplitude asin x,
+//
+//	arcsn(x|m) = F(asin x|m) = x·RF(1 - x², 1 - m x², 1)
+//
+// which EllipticFSin evaluates. x must be in [-1, 1] and m in [0, 1]. At
+// m = 1 and |x| = 1 the result is ±Inf.
+func JacobiSNInverse(x, m float64) float64 {
+	return EllipticFSin(x, m)
+}
+
+// JacobiCNInverse computes arccn(x|m), the u in [0, 2K] with cn(u|m) = x.
+// With s = √(1 - x²) (DLMF 22.15.13)
+//
+//	arccn(x|m) = s·RF(x², 1 - m + m x², 1),  x >= 0
+//	arccn(x|m) = 2K(m) - arccn(-x|m),        x < 0
+//
+// and 1 - x² is formed as (1 - x)(1 + x). x must be in [-1, 1] and m in
+// [0, 1]. At m = 1, cn is sech u, which is positive, so arccn(0|1) is
+// +Inf and a negative x gives NaN.
+func JacobiCNInverse(x, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || math.IsNaN(m) || x < -1 || x > 1 || m < 0 || m > 1 || (m == 1 && x < 0) {
+		return math.NaN()
+	}
+
+	// At m = 0 the functions are circular.
+	if m == 0 {
+		return math.Acos(x)
+	}
+
+	mc := 1 - m
+	a := math.Abs(x)
+	u := math.Sqrt((1-a)*(1+a)) * rf(a*a, mc+m*a*a, 1)
+	if x < 0 {
+		return 2*completeK(m, mc) - u
+	}
+
+	return u
+}
+
+// JacobiDNInverse computes arcdn(x|m), the u in [0, K] with dn(u|m) = x.
+// dn decreases from 1 to k' = √(1 - m) over [0, K], and with sn² =
+// (1 - x²)/m and cn² = (x² - k'²)/m the scaling of RF gives
+//
+//	arcdn(x|m) = √(1 - x²)·RF(x² - k'², m x², m)
+//
+// where x² - k'² is formed as (x - k')(x + k'). x must be in [k', 1] and
+// m in (0, 1]; at m = 0, dn is 1 everywhere and NaN is returned. At m = 1,
+// dn is sech u and arcdn(0|1) is +Inf.
+func JacobiDNInverse(x, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(x) || math.IsNaN(m) || m <= 0 || m > 1 || x > 1 {
+		return math.NaN()
+	}
+
+	kc := math.Sqrt(1 - m)
+	if x < kc {
+		return math.NaN()
+	}
+
+	return math.Sqrt((1-x)*(1+x)) * rf((x-kc)*(x+kc), m*x*x, m)
+}
+
+// JacobiPeriods returns 4K(m) and 4K(1-m), the sides of the period
+// rectangle shared by all three Jacobi functions. The individual
+// functions repeat on a finer lattice inside it:
+//
+//	sn(u|m)  periods 4K and 2iK'
+//	cn(u|m)  periods 4K and 2K + 2iK'
+//	dn(u|m)  periods 2K and 4iK'
+//
+// where K = K(m) and K' = K(1-m). At m = 0 the periods are 2π and +Inf,
+// at m = 1 they are +Inf and 2π. m must be in [0, 1].
+func JacobiPeriods(m float64) (realPeriod, imagPeriod float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN(), math.NaN()
+	}
+
+	mc := 1 - m
+	return 4 * completeK(m, mc), 4 * completeK(mc, m)
+}
+
+// JacobiWithEpsilon computes sn(u|m), cn(u|m) and dn(u|m) together with
+// the Jacobi epsilon function ε(u|m) = E(am(u|m)|m). The epsilon function
+// reuses the angles of the Landen transformation, so the fused call costs
+// about the same as Jacobi alone. m must be in [0, 1].
+func JacobiWithEpsilon(u, m float64) (sn, cn, dn, eps float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(u) || math.IsNaN(m) || m < 0 || m > 1 {
+		nan := math.NaN()
+		return nan, nan, nan, nan
+	}
+
+	// At m = 1 the functions degenerate and E(φ|1) = sin φ.
+	if m == 1 {
+		sech := 1 / math.Cosh(u)
+		sn = math.Tanh(u)
+		return sn, sech, sech, sn
+	}
+
+	// At m = 0 they are circular and E(φ|0) = φ = u.
+	if m == 0 {
+		sn, cn = math.Sincos(u)
+		return sn, cn, 1, u
+	}
+
+	// ε(u) = u·E(m)/K(m) + Σ cₙ sin φₙ.
+	d := newDescent(m, 1-m)
+	sn, cn, dn, sum := jacobiAt(d, nil, u)
+	return sn, cn, dn, u*d.ratio + sum
+}
+
+// JacobiEpsilon computes the Jacobi epsilon function
+//
+//	ε(u|m) = E(am(u|m)|m)
+//
+// from the angles of the Landen transformation, without going through
+// the amplitude and the incomplete integral. It is quasi-periodic with
+// ε(u + 2K|m) = ε(u|m) + 2E(m). m must be in [0, 1].
+func JacobiEpsilon(u, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(u) || math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	// At m = 1 the amplitude is gd(u) and E(φ|1) = sin φ, at m = 0 the
+	// amplitude is u and E(φ|0) = φ.
+	if m == 1 {
+		return math.Tanh(u)
+	}
+	if m == 0 {
+		return u
+	}
+
+	d := newDescent(m, 1-m)
+	_, sum := d.amplitude(u)
+
+	return u*d.ratio + sum
+}
+
+// JacobiZn computes the Jacobi zeta function in terms of u,
+//
+//	zn(u|m) = Z(am(u|m)|m) = ε(u|m) - u·E(m)/K(m)
+//
+// which is the periodic part of the epsilon function, with period 2K. The
+// Landen transformation gives it directly as Σ cₙ sin φₙ, so it keeps its
+// precision where it is small next to ε. m must be in [0, 1].
+func JacobiZn(u, m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(u) || math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	// At m = 1 the period is infinite and E(m)/K(m) vanishes, at m = 0
+	// ε(u) = u and the zeta function vanishes.
+	if m == 1 {
+		return math.Tanh(u)
+	}
+	if m == 0 {
+		return 0
+	}
+
+	_, sum := newDescent(m, 1-m).amplitude(u)
+	return sum
+}
+
+// JacobiGlaisher computes the Jacobi function pq(u|m) in Glaisher's
+// notation, where p and q are each one of the letters 's', 'c', 'd' and
+// 'n' and
+//
+//	pq(u|m) = p(u|m)/q(u|m),  s = sn, c = cn, d = dn, n = 1
+//
+// so that cd = cn/dn, sc = sn/cn, ns = 1/sn and pp = 1. All twelve
+// functions come from one evaluation of Jacobi. A pole, where q(u|m) is
+// zero, gives ±Inf with the sign of the zero. m must be in [0, 1] and
+// other letters give NaN.
+func JacobiGlaisher(u, m float64, p, q byte) float64 {
+
+	// Reject arguments outside of the domain.
+	i, j := strings.IndexByte("scdn", p), strings.IndexByte("scdn", q)
+	if math.IsNaN(u) || math.IsNaN(m) || m < 0 || m > 1 || i < 0 || j < 0 {
+		return math.NaN()
+	}
+
+	if i == j {
+		return 1
+	}
+
+	sn, cn, dn := jacobiFuncs(u, m, 1-m)
+	f := [...]float64{sn, cn, dn, 1}
+
+	return f[i] / f[j]
+}
+
+// JacobiGrid computes sn(u|m), cn(u|m) and dn(u|m) for every element of u
+// and stores them in sn, cn and dn. The Landen transformation only depends
+// on m, so it is set up once for the whole grid. The results match Jacobi
+// element by element. JacobiGrid panics if the slices have different
+// lengths.
+func JacobiGrid(sn, cn, dn, u []float64, m float64) {
+	if len(sn) != len(u) || len(cn) != len(u) || len(dn) != len(u) {
+		panic("mathext: slice length mismatch")
+	}
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		nan := math.NaN()
+		for i := range u {
+			sn[i], cn[i], dn[i] = nan, nan, nan
+		}
+		return
+	}
+
+	// At m = 1 the functions degenerate to hyperbolic functions and at
+	// m = 0 to circular ones.
+	if m == 0 || m == 1 {
+		for i, v := range u {
+			sn[i], cn[i], dn[i] = Jacobi(v, m)
+		}
+		return
+	}
+
+	d := newDescent(m, 1-m)
+	var g *nearOne
+	if d.mc < nearOneMc {
+		g = newNearOne(d.mc)
+	}
+	for i, v := range u {
+		if math.IsNaN(v) {
+			sn[i], cn[i], dn[i] = v, v, v
+			continue
+		}
+		sn[i], cn[i], dn[i], _ = jacobiAt(d, g, v)
+	}
+}
+
+// JacobiImaginary computes sn(iv|m), cn(iv|m) and dn(iv|m) for real v with
+// Jacobi's imaginary transformation
+//
+//	sn(iv|m) = i·sc(v|1-m),  cn(iv|m) = nc(v|1-m),  dn(iv|m) = dc(v|1-m)
+//
+// which needs a single real evaluation at the complementary parameter.
+// The functions have poles where cn(v|1-m) = 0 and return infinite parts
+// there. m must be in [0, 1].
+func JacobiImaginary(v, m float64) (sn, cn, dn complex128) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(v) || math.IsNaN(m) || m < 0 || m > 1 {
+		nan := cmplx.NaN()
+		return nan, nan, nan
+	}
+
+	s, c, d := jacobiFuncs(v, 1-m, m)
+	if c == 0 {
+		inf := math.Inf(1)
+		return complex(0, math.Copysign(inf, s)), complex(inf, 0), complex(math.Copysign(inf, d), 0)
+	}
+
+	return complex(0, s/c), complex(1/c, 0), complex(d/c, 0)
+}
+
+// JacobiComplex computes sn(u|m), cn(u|m) and dn(u|m) for complex u and
+// real m in [0, 1] from the addition formulas of Abramowitz and Stegun
+// 16.21, which combine the real functions of Re u at m with those of Im u
+// at 1 - m.
+func JacobiComplex(u complex128, m float64) (sn, cn, dn complex128) {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(u) || math.IsNaN(m) || m < 0 || m > 1 {
+		nan := cmplx.NaN()
+		return nan, nan, nan
+	}
+
+	mc := 1 - m
+	s, c, d := jacobiFuncs(real(u), m, mc)
+	s1, c1, d1 := jacobiFuncs(imag(u), mc, m)
+
+	// All three functions share the denominator cn²(y|mc) + m·sn²(x|m)·sn²(y|mc).
+	den := c1*c1 + m*s*s*s1*s1
+	sn = complex(s*d1/den, c*d*s1*c1/den)
+	cn = complex(c*c1/den, -s*d*s1*d1/den)
+	dn = complex(d*c1*d1/den, -m*s*c*s1/den)
+
+	return sn, cn, dn
+}
@@ -0,0 +1,526 @@
+package mathext_test
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestJacobi(t *testing.T) {
+	tt := []struct {
+		name       string
+		u, m       float64
+		sn, cn, dn float64
+	}{
+		{"small", 0.30044372425162613, 0.1, 0.2955202066613396, 0.955336489125606, 0.9956238148746162},
+		{"half", 1.340733523660133, 0.5, 0.9320390859672264, 0.36235775447667357, 0.7520981126918805},
+		{"nearOne", 3.03601409733971, 0.99, 0.9974949866040544, 0.0707372016677029, 0.12228538008600823},
+		{"negative", -0.864025026184176, 0.7, -0.7173560908995228, 0.6967067093471654, 0.7998625926961136},
+	}
+
+	t.Log("Given the need to evaluate the Jacobi elliptic functions.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking u=%v, m=%v.", testID, test.u, test.m)
+				{
+					sn, cn, dn := mathext.Jacobi(test.u, test.m)
+					if relErr(sn, test.sn) > 1e-13 || relErr(cn, test.cn) > 1e-13 || relErr(dn, test.dn) > 1e-13 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v %v %v, got %v %v %v.", failed, testID, test.sn, test.cn, test.dn, sn, cn, dn)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v %v %v.", succeed, testID, test.sn, test.cn, test.dn)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestJacobiNearOne(t *testing.T) {
+	tt := []struct {
+		name       string
+		u, mc      float64
+		sn, cn, dn float64
+	}{
+		{"u=1", 1, 0x1p-40, 0.7615941559558426, 0.6480542736637941, 0.6480542736642011},
+		{"u=3", 3, 0x1p-40, 0.99505475368695, 0.09932792741723409, 0.09932792742176716},
+		{"u=6", 6, 0x1p-40, 0.999987711651023, 0.0049574738477034435, 0.00495747393943084},
+		{"u=10", 10, 0x1p-40, 0.9999999958779201, 9.079735521879679e-05, 9.08023634572506e-05},
+		{"split", 8, 0x1p-20, 0.9999999502080539, 0.000315569152165928, 0.0010262836407321197},
+		{"tiny", 15, 0x1p-50, 0.999999999999813, 6.114417071681146e-07, 6.121675748390781e-07},
+	}
+
+	t.Log("Given the need to keep cn and dn precise where they decay next to m = 1.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking u=%v, mc=%v.", testID, test.u, test.mc)
+				{
+					sn, cn, dn := mathext.Jacobi(test.u, 1-test.mc)
+					if math.Abs(sn-test.sn) > 4*ulp(test.sn) || math.Abs(cn-test.cn) > 32*ulp(test.cn) || math.Abs(dn-test.dn) > 32*ulp(test.dn) {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v %v %v, got %v %v %v.", failed, testID, test.sn, test.cn, test.dn, sn, cn, dn)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v %v %v.", succeed, testID, test.sn, test.cn, test.dn)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		testID := len(tt)
+		t.Logf("\tTest %d:\tWhen checking the identities at mc = 1e-12.", testID)
+		{
+			const m = 1 - 1e-12
+			for u := 0.0; u <= 10; u += 0.125 {
+				sn, cn, dn := mathext.Jacobi(u, m)
+				if e := math.Abs(sn*sn + cn*cn - 1); e > 2*ulp(1) {
+					t.Fatalf("\t%s\tTest %d:\tShould have sn² + cn² = 1 at u=%v, off by %v.", failed, testID, u, e)
+				}
+				if e := math.Abs(m*sn*sn + dn*dn - 1); e > 2*ulp(1) {
+					t.Fatalf("\t%s\tTest %d:\tShould have m·sn² + dn² = 1 at u=%v, off by %v.", failed, testID, u, e)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould satisfy the identities to 2 ulp.", succeed, testID)
+		}
+	}
+}
+
+func TestJacobiPeriods(t *testing.T) {
+	t.Log("Given the need to know the periods of the Jacobi functions.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the limits m = 0 and m = 1.")
+		{
+			if re, im := mathext.JacobiPeriods(0); re != 2*math.Pi || !math.IsInf(im, 1) {
+				t.Fatalf("\t%s\tTest 0:\tShould get 2π and +Inf at m = 0, got %v and %v.", failed, re, im)
+			}
+			if re, im := mathext.JacobiPeriods(1); !math.IsInf(re, 1) || im != 2*math.Pi {
+				t.Fatalf("\t%s\tTest 0:\tShould get +Inf and 2π at m = 1, got %v and %v.", failed, re, im)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get the circular and hyperbolic periods.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen approaching the limits.")
+		{
+			re, im := mathext.JacobiPeriods(1e-10)
+			if relErr(re, 2*math.Pi) > 1e-10 || im < 40 {
+				t.Fatalf("\t%s\tTest 1:\tShould approach 2π and grow without bound near m = 0, got %v and %v.", failed, re, im)
+			}
+			re, im = mathext.JacobiPeriods(1 - 1e-10)
+			if re < 40 || relErr(im, 2*math.Pi) > 1e-10 {
+				t.Fatalf("\t%s\tTest 1:\tShould grow without bound and approach 2π near m = 1, got %v and %v.", failed, re, im)
+			}
+			t.Logf("\t%s\tTest 1:\tShould approach the limits.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen shifting the argument by a period.")
+		{
+			const m, u = 0.7, 0.4
+			re, _ := mathext.JacobiPeriods(m)
+			sn, cn, dn := mathext.Jacobi(u, m)
+			sn4, cn4, _ := mathext.Jacobi(u+re, m)
+			_, _, dn2 := mathext.Jacobi(u+re/2, m)
+			if math.Abs(sn4-sn) > 1e-14 || math.Abs(cn4-cn) > 1e-14 || math.Abs(dn2-dn) > 1e-14 {
+				t.Fatalf("\t%s\tTest 2:\tShould repeat sn and cn after 4K and dn after 2K.", failed)
+			}
+			t.Logf("\t%s\tTest 2:\tShould repeat sn and cn after 4K and dn after 2K.", succeed)
+		}
+	}
+}
+
+func TestJacobiAmplitudeWinding(t *testing.T) {
+	tt := []struct {
+		u, m, am float64
+	}{
+		{123.456, 0.5, 104.67565383428044},
+		{98765.4321, 0.5, 83675.2861803775},
+		{3.3e9, 0.9, 2.0106449460869832e9},
+		{7.1e13, 0.9, 4.325933065967865e13},
+		{98765.4321, 0.999999, 18704.910318914488},
+	}
+
+	t.Log("Given the need to follow the amplitude over many quarter periods.")
+	{
+		t.Logf("\tTest 0:\tWhen checking far from the origin.")
+		{
+			for _, test := range tt {
+				if am := mathext.JacobiAmplitude(test.u, test.m); math.Abs(am-test.am) > 4*ulp(test.am) {
+					t.Fatalf("\t%s\tTest 0:\tShould get am(%v|%v) = %v, got %v.", failed, test.u, test.m, test.am, am)
+				}
+				if am := mathext.JacobiAmplitude(-test.u, test.m); math.Abs(am+test.am) > 4*ulp(test.am) {
+					t.Fatalf("\t%s\tTest 0:\tShould get am(%v|%v) = %v, got %v.", failed, -test.u, test.m, -test.am, am)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould keep the winding number.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen stepping through quarter periods.")
+		{
+			for _, m := range []float64{0.1, 0.5, 0.9, 0.999} {
+				k := mathext.CompleteK(m)
+				for j := -40; j <= 40; j++ {
+					want := float64(j) * math.Pi / 2
+					if am := mathext.JacobiAmplitude(float64(j)*k, m); math.Abs(am-want) > 1e-13*math.Max(1, math.Abs(want)) {
+						t.Fatalf("\t%s\tTest 1:\tShould get am(%dK|%v) = %v, got %v.", failed, j, m, want, am)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get am(jK|m) = jπ/2.", succeed)
+		}
+	}
+}
+
+func TestJacobiInverse(t *testing.T) {
+	tt := []struct {
+		name string
+		fn   func(x, m float64) float64
+		x, m float64
+		want float64
+	}{
+		{"sn", mathext.JacobiSNInverse, 0.6, 0.3, 0.6564180970395754},
+		{"sn", mathext.JacobiSNInverse, -0.999, 0.9, -2.4370797714104486},
+		{"sn", mathext.JacobiSNInverse, 0.25, 0.999999, 0.25541280906953173},
+		{"cn", mathext.JacobiCNInverse, 0.6, 0.3, 0.9643310928768182},
+		{"cn", mathext.JacobiCNInverse, -0.4, 0.7, 2.7855217598779225},
+		{"cn", mathext.JacobiCNInverse, 0.001, 0.99, 3.685637526315906},
+		{"cn", mathext.JacobiCNInverse, -0.999999, 0.5, 3.706735140686797},
+		{"dn", mathext.JacobiDNInverse, 0.9, 0.3, 0.9566256006832331},
+		{"dn", mathext.JacobiDNInverse, 0.55, 0.7, 1.966340276456347},
+		{"dn", mathext.JacobiDNInverse, 0.02, 0.9999, 4.674511897376367},
+		{"dn", mathext.JacobiDNInverse, 0.999999, 0.5, 0.002000001500030993},
+	}
+
+	t.Log("Given the need to invert the Jacobi elliptic functions.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with high precision values.")
+		{
+			for _, test := range tt {
+				if got := test.fn(test.x, test.m); math.Abs(got-test.want) > 4*ulp(test.want) {
+					t.Fatalf("\t%s\tTest 0:\tShould get arc%s(%v|%v) = %v, got %v.", failed, test.name, test.x, test.m, test.want, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match to a few ulp.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen applying the functions to the inverses.")
+		{
+			for _, m := range []float64{0, 0.1, 0.5, 0.9, 0.999, 1} {
+				kc := math.Sqrt(1 - m)
+				for x := -1.0; x <= 1; x += 1.0 / 64 {
+					if m < 1 || math.Abs(x) < 1 {
+						if sn, _, _ := mathext.Jacobi(mathext.JacobiSNInverse(x, m), m); math.Abs(sn-x) > 4e-15 {
+							t.Fatalf("\t%s\tTest 1:\tShould get sn(arcsn(%v|%v)) = %v, got %v.", failed, x, m, x, sn)
+						}
+					}
+					if m < 1 || x > 0 {
+						if _, cn, _ := mathext.Jacobi(mathext.JacobiCNInverse(x, m), m); math.Abs(cn-x) > 4e-15 {
+							t.Fatalf("\t%s\tTest 1:\tShould get cn(arccn(%v|%v)) = %v, got %v.", failed, x, m, x, cn)
+						}
+					}
+					if y := kc + (1-kc)*(x+1)/2; m > 0 && y > 0 {
+						if _, _, dn := mathext.Jacobi(mathext.JacobiDNInverse(y, m), m); math.Abs(dn-y) > 4e-15 {
+							t.Fatalf("\t%s\tTest 1:\tShould get dn(arcdn(%v|%v)) = %v, got %v.", failed, y, m, y, dn)
+						}
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get x back.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen x is at the ends of or outside of the range.")
+		{
+			for _, m := range []float64{0.3, 0.9} {
+				k := mathext.CompleteK(m)
+				if u := mathext.JacobiCNInverse(-1, m); math.Abs(u-2*k) > 2*ulp(k) {
+					t.Fatalf("\t%s\tTest 2:\tShould get arccn(-1|%v) = 2K, got %v.", failed, m, u)
+				}
+				if u := mathext.JacobiDNInverse(math.Sqrt(1-m), m); math.Abs(u-k) > 2*ulp(k) {
+					t.Fatalf("\t%s\tTest 2:\tShould get arcdn(k'|%v) = K, got %v.", failed, m, u)
+				}
+				if mathext.JacobiCNInverse(1, m) != 0 || mathext.JacobiDNInverse(1, m) != 0 {
+					t.Fatalf("\t%s\tTest 2:\tShould get 0 at x = 1.", failed)
+				}
+			}
+			if !math.IsInf(mathext.JacobiCNInverse(0, 1), 1) || !math.IsInf(mathext.JacobiDNInverse(0, 1), 1) {
+				t.Fatalf("\t%s\tTest 2:\tShould get +Inf at x = 0 and m = 1.", failed)
+			}
+			for _, v := range [][3]float64{{1.5, -0.5, 0.3}, {0.5, -0.5, 1.5}, {-1.5, 1.5, 0.5}} {
+				x, y, m := v[0], v[1], v[2]
+				if !math.IsNaN(mathext.JacobiSNInverse(x, m)) || !math.IsNaN(mathext.JacobiCNInverse(x, m)) || !math.IsNaN(mathext.JacobiDNInverse(y, m)) {
+					t.Fatalf("\t%s\tTest 2:\tShould get NaN at x=%v, %v and m=%v.", failed, x, y, m)
+				}
+			}
+			if !math.IsNaN(mathext.JacobiCNInverse(-0.5, 1)) || !math.IsNaN(mathext.JacobiDNInverse(1, 0)) || !math.IsNaN(mathext.JacobiDNInverse(0.5, 0.5)) {
+				t.Fatalf("\t%s\tTest 2:\tShould get NaN where no real u exists.", failed)
+			}
+			t.Logf("\t%s\tTest 2:\tShould get 0, K, 2K, +Inf and NaN.", succeed)
+		}
+	}
+}
+
+func TestJacobiWithEpsilon(t *testing.T) {
+	t.Log("Given the need to compute the Jacobi epsilon with the Jacobi functions.")
+	{
+		for testID, m := range []float64{0, 0.1, 0.5, 0.9, 0.999, 1} {
+			t.Logf("\tTest %d:\tWhen checking m=%v.", testID, m)
+			{
+				for u := -6.0; u <= 6; u += 0.25 {
+					sn, cn, dn, eps := mathext.JacobiWithEpsilon(u, m)
+
+					want := mathext.EllipticE(mathext.JacobiAmplitude(u, m), m)
+					if e := relErr(eps, want); e > 1e-13 {
+						t.Fatalf("\t%s\tTest %d:\tShould get ε(%v)=%v, got %v : rel err %g.", failed, testID, u, want, eps, e)
+					}
+
+					wsn, wcn, wdn := mathext.Jacobi(u, m)
+					if sn != wsn || cn != wcn || dn != wdn {
+						t.Fatalf("\t%s\tTest %d:\tShould match Jacobi at u=%v.", failed, testID, u)
+					}
+				}
+				t.Logf("\t%s\tTest %d:\tShould match EllipticE(JacobiAmplitude(u, m), m) and Jacobi.", succeed, testID)
+			}
+		}
+	}
+}
+
+func TestJacobiEpsilonZn(t *testing.T) {
+	t.Log("Given the need to compute the Jacobi epsilon and zeta functions.")
+	{
+		for testID, m := range []float64{0, 0.1, 0.5, 0.9, 0.999} {
+			t.Logf("\tTest %d:\tWhen checking m=%v.", testID, m)
+			{
+				k, e := mathext.CompleteK(m), mathext.CompleteE(m)
+				for u := -6.0; u <= 6; u += 0.25 {
+					eps, zn := mathext.JacobiEpsilon(u, m), mathext.JacobiZn(u, m)
+
+					_, _, _, want := mathext.JacobiWithEpsilon(u, m)
+					if eps != want {
+						t.Fatalf("\t%s\tTest %d:\tShould match JacobiWithEpsilon at u=%v: got %v, want %v.", failed, testID, u, eps, want)
+					}
+
+					if d := math.Abs(eps - (zn + e*u/k)); d > 1e-14*math.Max(1, math.Abs(eps)) {
+						t.Fatalf("\t%s\tTest %d:\tShould get ε = zn + E·u/K at u=%v: diff %g.", failed, testID, u, d)
+					}
+
+					if d := math.Abs(mathext.JacobiZn(u+2*k, m) - zn); d > 1e-14 {
+						t.Fatalf("\t%s\tTest %d:\tShould repeat zn after 2K at u=%v: diff %g.", failed, testID, u, d)
+					}
+
+					want = eps + 2*e
+					if d := math.Abs(mathext.JacobiEpsilon(u+2*k, m) - want); d > 1e-14*math.Max(1, math.Abs(want)) {
+						t.Fatalf("\t%s\tTest %d:\tShould get ε(u+2K) = ε(u) + 2E at u=%v: diff %g.", failed, testID, u, d)
+					}
+				}
+				t.Logf("\t%s\tTest %d:\tShould get ε = zn + E·u/K with zn periodic and ε quasi-periodic.", succeed, testID)
+			}
+		}
+
+		t.Logf("\tTest 5:\tWhen checking m=1.")
+		{
+			for _, u := range []float64{-3, 0, 0.5, 4} {
+				if eps, zn := mathext.JacobiEpsilon(u, 1), mathext.JacobiZn(u, 1); eps != math.Tanh(u) || zn != math.Tanh(u) {
+					t.Fatalf("\t%s\tTest 5:\tShould get ε = zn = tanh u at u=%v, got %v and %v.", failed, u, eps, zn)
+				}
+			}
+			t.Logf("\t%s\tTest 5:\tShould get ε = zn = tanh u.", succeed)
+		}
+	}
+}
+
+var sn, cn, dn, eps float64
+
+func BenchmarkJacobiWithEpsilon(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sn, cn, dn, eps = mathext.JacobiWithEpsilon(1.3, 0.7)
+	}
+}
+
+func BenchmarkJacobiThenEpsilon(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sn, cn, dn = mathext.Jacobi(1.3, 0.7)
+		eps = mathext.EllipticE(mathext.JacobiAmplitude(1.3, 0.7), 0.7)
+	}
+}
+
+func TestJacobiGrid(t *testing.T) {
+	u := make([]float64, 201)
+	for i := range u {
+		u[i] = -10 + 0.1*float64(i)
+	}
+	sn := make([]float64, len(u))
+	cn := make([]float64, len(u))
+	dn := make([]float64, len(u))
+
+	t.Log("Given the need to evaluate the Jacobi functions over a grid.")
+	{
+		for testID, m := range []float64{0, 0.3, 0.9, 0.999999, 1} {
+			t.Logf("\tTest %d:\tWhen checking m=%v.", testID, m)
+			{
+				mathext.JacobiGrid(sn, cn, dn, u, m)
+				for i, v := range u {
+					s, c, d := mathext.Jacobi(v, m)
+					if sn[i] != s || cn[i] != c || dn[i] != d {
+						t.Fatalf("\t%s\tTest %d:\tShould match Jacobi at u=%v : got %v %v %v, want %v %v %v.", failed, testID, v, sn[i], cn[i], dn[i], s, c, d)
+					}
+				}
+				t.Logf("\t%s\tTest %d:\tShould match Jacobi element by element.", succeed, testID)
+			}
+		}
+	}
+}
+
+func benchGrid() []float64 {
+	u := make([]float64, 1024)
+	for i := range u {
+		u[i] = 0.01 * float64(i)
+	}
+	return u
+}
+
+func BenchmarkJacobiGrid(b *testing.B) {
+	u := benchGrid()
+	sn, cn, dn := make([]float64, len(u)), make([]float64, len(u)), make([]float64, len(u))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		mathext.JacobiGrid(sn, cn, dn, u, 0.7)
+	}
+}
+
+func BenchmarkJacobiLoop(b *testing.B) {
+	u := benchGrid()
+	sn, cn, dn := make([]float64, len(u)), make([]float64, len(u)), make([]float64, len(u))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j, v := range u {
+			sn[j], cn[j], dn[j] = mathext.Jacobi(v, 0.7)
+		}
+	}
+}
+
+func TestJacobiImaginary(t *testing.T) {
+	t.Log("Given the need to evaluate the Jacobi functions on the imaginary axis.")
+	{
+		for testID, m := range []float64{0, 0.2, 0.5, 0.9, 1} {
+			t.Logf("\tTest %d:\tWhen checking m=%v.", testID, m)
+			{
+				for _, v := range []float64{-2, -0.3, 0, 0.7, 1.5} {
+					sn, cn, dn := mathext.JacobiImaginary(v, m)
+					wsn, wcn, wdn := mathext.JacobiComplex(complex(0, v), m)
+					if cmplx.Abs(sn-wsn) > 1e-14*cmplx.Abs(wsn) || cmplx.Abs(cn-wcn) > 1e-14*cmplx.Abs(wcn) || cmplx.Abs(dn-wdn) > 1e-14*cmplx.Abs(wdn) {
+						t.Fatalf("\t%s\tTest %d:\tShould match JacobiComplex at v=%v : got %v %v %v, want %v %v %v.", failed, testID, v, sn, cn, dn, wsn, wcn, wdn)
+					}
+					if e := cmplx.Abs(sn*sn + cn*cn - 1); e > 1e-14*cmplx.Abs(cn*cn) {
+						t.Fatalf("\t%s\tTest %d:\tShould have sn² + cn² = 1 at v=%v.", failed, testID, v)
+					}
+				}
+				t.Logf("\t%s\tTest %d:\tShould match JacobiComplex.", succeed, testID)
+			}
+		}
+
+		t.Logf("\tTest 5:\tWhen checking the closed forms at m = 0.")
+		{
+			sn, cn, dn := mathext.JacobiImaginary(0.8, 0)
+			if relErr(imag(sn), math.Sinh(0.8)) > 1e-15 || relErr(real(cn), math.Cosh(0.8)) > 1e-15 || dn != 1 {
+				t.Fatalf("\t%s\tTest 5:\tShould get i·sinh, cosh and 1, got %v %v %v.", failed, sn, cn, dn)
+			}
+			t.Logf("\t%s\tTest 5:\tShould get i·sinh, cosh and 1.", succeed)
+		}
+
+		t.Logf("\tTest 6:\tWhen checking the pole at v = K(1-m).")
+		{
+			sn, cn, _ := mathext.JacobiImaginary(mathext.CompleteK(0.5), 0.5)
+			if math.Abs(imag(sn)) < 1e15 || math.Abs(real(cn)) < 1e15 {
+				t.Fatalf("\t%s\tTest 6:\tShould blow up at the pole, got %v and %v.", failed, sn, cn)
+			}
+			t.Logf("\t%s\tTest 6:\tShould blow up at the pole.", succeed)
+		}
+	}
+}
+
+func TestJacobiComplex(t *testing.T) {
+	t.Log("Given the need to evaluate the Jacobi functions for a complex argument.")
+	{
+		t.Logf("\tTest 0:\tWhen checking a real argument.")
+		{
+			for _, u := range []float64{-1, 0.4, 2.2} {
+				sn, cn, dn := mathext.JacobiComplex(complex(u, 0), 0.6)
+				s, c, d := mathext.Jacobi(u, 0.6)
+				if sn != complex(s, 0) || cn != complex(c, 0) || dn != complex(d, 0) {
+					t.Fatalf("\t%s\tTest 0:\tShould match Jacobi at u=%v : got %v %v %v.", failed, u, sn, cn, dn)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match Jacobi.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the identities off the axes.")
+		{
+			for _, u := range []complex128{0.3 + 0.4i, -1.1 + 0.9i, 2 - 0.5i} {
+				const m = 0.7
+				sn, cn, dn := mathext.JacobiComplex(u, m)
+				if cmplx.Abs(sn*sn+cn*cn-1) > 1e-14 || cmplx.Abs(m*sn*sn+dn*dn-1) > 1e-14 {
+					t.Fatalf("\t%s\tTest 1:\tShould satisfy sn² + cn² = 1 and m·sn² + dn² = 1 at u=%v.", failed, u)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould satisfy the identities.", succeed)
+		}
+	}
+}
+
+func TestJacobiGlaisher(t *testing.T) {
+	t.Log("Given the need to evaluate the twelve Jacobi functions.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with the quotients of sn, cn and dn.")
+		{
+			for _, m := range []float64{0, 0.3, 0.8, 1} {
+				for u := -3.0; u <= 3; u += 0.375 {
+					sn, cn, dn := mathext.Jacobi(u, m)
+					f := map[byte]float64{'s': sn, 'c': cn, 'd': dn, 'n': 1}
+					for _, p := range []byte("scdn") {
+						for _, q := range []byte("scdn") {
+							want := f[p] / f[q]
+							if p == q {
+								want = 1
+							}
+							if got := mathext.JacobiGlaisher(u, m, p, q); got != want {
+								t.Fatalf("\t%s\tTest 0:\tShould get %c%c(%v|%v) = %v, got %v.", failed, p, q, u, m, want, got)
+							}
+						}
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get pq = p/q with pp = 1.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen shifting by a quarter period.")
+		{
+
+			// sn(u + K) = cd(u), cn(u + K) = -k'·sd(u), dn(u + K) = k'·nd(u).
+			for _, m := range []float64{0.2, 0.5, 0.9} {
+				k, kc := mathext.CompleteK(m), math.Sqrt(1-m)
+				for u := -2.0; u <= 2; u += 0.25 {
+					sn, cn, dn := mathext.Jacobi(u+k, m)
+					cd := mathext.JacobiGlaisher(u, m, 'c', 'd')
+					sd := mathext.JacobiGlaisher(u, m, 's', 'd')
+					nd := mathext.JacobiGlaisher(u, m, 'n', 'd')
+					if math.Abs(sn-cd) > 1e-15 || math.Abs(cn+kc*sd) > 1e-15 || math.Abs(dn-kc*nd) > 1e-15 {
+						t.Fatalf("\t%s\tTest 1:\tShould get cd, -k'·sd and k'·nd at u=%v, m=%v: got %v %v %v, want %v %v %v.", failed, u, m, cd, -kc*sd, kc*nd, sn, cn, dn)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould match sn, cn and dn at u + K.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking poles and arguments outside of the domain.")
+		{
+			if ns, cs := mathext.JacobiGlaisher(0, 0.5, 'n', 's'), mathext.JacobiGlaisher(0, 0.5, 'c', 's'); !math.IsInf(ns, 1) || !math.IsInf(cs, 1) {
+				t.Fatalf("\t%s\tTest 2:\tShould get +Inf for ns(0) and cs(0), got %v %v.", failed, ns, cs)
+			}
+			if !math.IsNaN(mathext.JacobiGlaisher(0.5, 0.5, 'x', 'n')) || !math.IsNaN(mathext.JacobiGlaisher(0.5, 0.5, 's', 'S')) || !math.IsNaN(mathext.JacobiGlaisher(0.5, 1.5, 's', 'c')) || !math.IsNaN(mathext.JacobiGlaisher(math.NaN(), 0.5, 's', 's')) {
+				t.Fatalf("\t%s\tTest 2:\tShould get NaN for other letters, m or u.", failed)
+			}
+			t.Logf("\t%s\tTest 2:\tShould get Inf at the poles and NaN outside of the domain.", succeed)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestLegendreRelationResidual(t *testing.T) {
+	t.Log("Given the need to validate the complete integrals with Legendre's relation.")
+	{
+		t.Logf("\tTest 0:\tWhen sweeping m across (0, 1).")
+		{
+			for i := 1; i < 1000; i++ {
+				m := float64(i) / 1000
+				if r := mathext.LegendreRelationResidual(m); math.Abs(r) > 8*ulp(math.Pi/2) {
+					t.Fatalf("\t%s\tTest 0:\tShould stay within 8 ulp at m=%v, got %v ulp.", failed, m, r/ulp(math.Pi/2))
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould stay within 8 ulp.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen m is at an endpoint.")
+		{
+			for _, m := range []float64{0, 1} {
+				if r := mathext.LegendreRelationResidual(m); !math.IsNaN(r) {
+					t.Fatalf("\t%s\tTest 1:\tShould get NaN at m=%v, got %v.", failed, m, r)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get NaN.", succeed)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package mathext_test
+
+import "math"
+
+const succeed = "\u2713"
+const failed = "\u2717"
+
+// relErr returns the relative error of got with respect to want, falling
+// back to the absolute error when want is zero.
+func relErr(got, want float64) float64 {
+	if got == want {
+		return 0
+	}
+	if want == 0 {
+		return math.Abs(got)
+	}
+	return math.Abs((got - want) / want)
+}
+
+// ulp returns the spacing between x and the next float64 away from zero.
+func ulp(x float64) float64 {
+	x = math.Abs(x)
+	return math.Nextafter(x, math.Inf(1)) - x
+}
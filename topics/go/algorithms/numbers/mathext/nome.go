@@ -0,0 +1,277 @@
+package mathext
+
+import "math"
+
+// nomeSeries evaluates q/ε = 1 + 2ε⁴ + 15ε⁸ + 150ε¹² + ... (A&S 17.3.21),
+// which converges quickly for the ε ≤ ½(√2-1)/(√2+1) used in this file.
+func nomeSeries(eps float64) float64 {
+	e4 := eps * eps * eps * eps
+	return 1 + e4*(2+e4*(15+e4*(150+e4*(1707+e4*(20910+e4*268616)))))
+}
+
+// nomeEps returns ε = ½(1 - √k')/(1 + √k') for the nome of m, where
+// k' = √mc. The difference 1 - √k' is formed from m directly so that it
+// keeps full precision when m is small.
+func nomeEps(m, mc float64) float64 {
+	r := math.Sqrt(math.Sqrt(mc))
+	return m / ((1 + math.Sqrt(mc)) * (1 + r)) / (2 * (1 + r))
+}
+
+// nome computes the nome q(m) = exp(-πK(1-m)/K(m)) for m in [0, 0.5]
+// together with its logarithm.
+func nome(m, mc float64) (q, lnq float64) {
+	eps := nomeEps(m, mc)
+	s := nomeSeries(eps)
+
+	return eps * s, math.Log(eps) + math.Log1p(s-1)
+}
+
+// theta3NullSquaredM1 computes θ₃(0, q)² - 1 for q <= e^(-π) from the
+// sum of two squares series θ₃(0, q)² = Σ r₂(n)·qⁿ. Returning the
+// difference from 1 lets callers add it to a leading term without an
+// extra rounding.
+func theta3NullSquaredM1(q float64) float64 {
+	// r₂(n)/4 for n = 1 through 13. The next term is q¹⁶.
+	q2 := q * q
+	q4 := q2 * q2
+	return 4 * q * (1 + q + q2*q*(1+2*q+q4*(1+q+2*q2+2*q4*q)))
+}
+
+// CompleteKNome computes K(m) from the theta-function representation
+// K(m) = (π/2)·θ₃(0, q)², where q is the nome of m. For m > 0.5 the
+// complementary nome q' of 1 - m is used instead through
+// K(m) = -½·ln(q')·θ₃(0, q')², which keeps q' below e^(-π) so the series
+// converge in a handful of terms. The logarithm is exact in the
+// representation, which makes this path accurate as m approaches 1.
+// m must be in [0, 1].
+func CompleteKNome(m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+	if m == 1 {
+		return math.Inf(1)
+	}
+
+	return completeKNome(m, 1-m)
+}
+
+// completeKNome evaluates K(m) through the nome for m in [0, 1) given both
+// m and its complement mc = 1 - m.
+func completeKNome(m, mc float64) float64 {
+	if m <= 0.5 {
+		q, _ := nome(m, mc)
+		return math.Pi/2 + math.Pi/2*theta3NullSquaredM1(q)
+	}
+
+	q, lnq := nome(mc, m)
+	h := -lnq / 2
+	return h + h*theta3NullSquaredM1(q)
+}
+
+// Nome computes the nome q(m) = exp(-π·K(1-m)/K(m)). m must be in [0, 1],
+// q(0) = 0 and q(1) = 1. For m > 0.5 the nome follows from the
+// complementary nome q' through ln q·ln q' = π², so both halves of the
+// range come from the fast converging series in ε.
+func Nome(m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	switch {
+	case m == 0:
+		return 0
+	case m == 1:
+		return 1
+	case m <= 0.5:
+		q, _ := nome(m, 1-m)
+		return q
+	}
+
+	_, lnqc := nome(1-m, m)
+	return math.Exp(math.Pi * math.Pi / lnqc)
+}
+
+// ComplementaryNome computes the complementary nome
+//
+//	q₁(m) = exp(-π·K(m)/K(1-m)) = q(1-m)
+//
+// which is linked to the nome by ln q·ln q₁ = π². The theta functions
+// accept either of them: with q₁ in place of q they describe the lattice
+// with the roles of K and K' exchanged, so θ₃(0, q₁)² = 2K(1-m)/π. Series
+// in q converge fastest for m below 1/2 and series in q₁ for m above it,
+// where q₁ <= e^-π. m must be in [0, 1], q₁(0) = 1 and q₁(1) = 0. Unlike
+// Nome(1 - m) the complement is never formed for m < 1/2, so small m keep
+// their precision.
+func ComplementaryNome(m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	switch {
+	case m == 0:
+		return 1
+	case m == 1:
+		return 0
+	case m >= 0.5:
+		q, _ := nome(1-m, m)
+		return q
+	}
+
+	_, lnq := nome(m, 1-m)
+	return math.Exp(math.Pi * math.Pi / lnq)
+}
+
+// PeriodRatio computes K(1-m)/K(m), the magnitude of the purely imaginary
+// period ratio τ = i·K'(m)/K(m). m must be in [0, 1]. The ratio grows
+// without bound as m goes to 0 and falls to 0 as m goes to 1, and it is
+// obtained from the logarithm of the nome, τ = -ln q/π, rather than from
+// two separate integrals.
+func PeriodRatio(m float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || m < 0 || m > 1 {
+		return math.NaN()
+	}
+
+	return periodRatio(m, 1-m)
+}
+
+// periodRatio computes K(mc)/K(m) for m + mc = 1 from the logarithm of the
+// nome of the smaller one.
+func periodRatio(m, mc float64) float64 {
+	switch {
+	case m == 0:
+		return math.Inf(1)
+	case mc == 0:
+		return 0
+	case m <= 0.5:
+		_, lnq := nome(m, mc)
+		return -lnq / math.Pi
+	}
+
+	_, lnqc := nome(mc, m)
+	return -math.Pi / lnqc
+}
+
+// ParameterFromPeriods recovers the parameter m of a rectangular lattice
+// with the real and imaginary half-periods ω and iω', the inverse of
+// PeriodRatio. m is the parameter with K(1-m)/K(m) = ω'/ω and k = K(m),
+// so the lattice is the one of the Jacobi functions scaled by ω/K(m),
+// sn(u|m) with u = K(m)·z/ω has the half-periods ω and iω' in z.
+//
+// For ω' >= ω the parameter follows from the nome q = exp(-π·ω'/ω), which
+// is at most e^-π, and for ω' < ω the complement follows from the
+// complementary nome exp(-π·ω/ω'), so the series in q always converge
+// fast and the smaller of m and 1 - m keeps its relative precision. ω and
+// ω' must be positive, ω' = 0 gives m = 1 and ω' = +Inf gives m = 0.
+func ParameterFromPeriods(omega, omegaPrime float64) (m, k float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(omega) || math.IsNaN(omegaPrime) || omega <= 0 || math.IsInf(omega, 1) || omegaPrime < 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	switch {
+	case omegaPrime == 0:
+		return 1, math.Inf(1)
+	case math.IsInf(omegaPrime, 1):
+		return 0, math.Pi / 2
+	}
+
+	var mc float64
+	if omegaPrime >= omega {
+		m, _ = NomeInverseValDeriv(nomeFromRatio(omegaPrime, omega))
+		mc = 1 - m
+	} else {
+		mc, _ = NomeInverseValDeriv(nomeFromRatio(omega, omegaPrime))
+		m = 1 - mc
+	}
+
+	return m, completeK(m, mc)
+}
+
+// nomeFromRatio computes exp(-π·a/b). The exponential turns the absolute
+// error of its argument into a relative error of the result, so π·a/b is
+// carried with the rounding errors of the quotient and of the product.
+func nomeFromRatio(a, b float64) float64 {
+	tau := a / b
+	tauLo := math.FMA(-tau, b, a) / b
+	x := math.Pi * tau
+	xLo := math.FMA(math.Pi, tau, -x) + math.Pi*tauLo + 2*piOver2Lo*tau
+
+	return math.Exp(-x) * (1 - xLo)
+}
+
+// NomeInverse recovers the parameter m from the nome q, the inverse of
+// Nome. Up to q = e^-π, where m <= 1/2, it is m = θ₂⁴/θ₃⁴ as in
+// NomeInverseValDeriv. Above it the complementary nome
+//
+//	q' = exp(π²/ln q)
+//
+// is at most e^-π and gives the complement 1 - m the same way, so the
+// series converge fast over the whole range. q must be in [0, 1], q = 0
+// gives m = 0 and q = 1 gives m = 1.
+func NomeInverse(q float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+
+	switch {
+	case q == 1:
+		return 1
+	case q <= math.Exp(-math.Pi):
+		m, _ := NomeInverseValDeriv(q)
+		return m
+	}
+
+	mc, _ := NomeInverseValDeriv(math.Exp(math.Pi * math.Pi / math.Log(q)))
+	return 1 - mc
+}
+
+// NomeInverseValDeriv recovers the parameter m from the nome q together
+// with the derivative dm/dq. With the theta constants θⱼ = θⱼ(0, q),
+//
+//	m = θ₂⁴/θ₃⁴,  dm/dq = θ₂⁴θ₄⁴/(q·θ₃⁴) = 4m(1-m)K(m)²/(π²q)
+//
+// where the second form of the derivative follows from
+// dq/dm = π²q/(4m(1-m)K²) and K = π/2·θ₃². θ₂⁴ carries a factor q which
+// is cancelled analytically, so dm/dq = 16 at q = 0. q must be in [0, 1).
+func NomeInverseValDeriv(q float64) (m, dmdq float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(q) || q < 0 || q >= 1 {
+		return math.NaN(), math.NaN()
+	}
+
+	// θ₂(0, q) = 2q^{1/4}·S with S = Σ q^{n(n+1)}, so θ₂⁴ = 16q·S⁴.
+	s, w, step := 0.0, 1.0, q*q
+	for w > 0x1p-54*s {
+		s += w
+		w *= step
+		step *= q * q
+	}
+
+	t3, t4 := Theta3(0, q), Theta4(0, q)
+	r := s / t3
+	r4 := 16 * r * r * r * r
+	u := t4 / t3
+	u *= u
+	mc := u * u
+
+	// Close to m = 1 the complement 1-m = θ₄⁴/θ₃⁴ is the accurate one.
+	m = q * r4
+	if mc < 0.5 {
+		m = 1 - mc
+	}
+
+	t4 *= t4
+	return m, r4 * t4 * t4
+}
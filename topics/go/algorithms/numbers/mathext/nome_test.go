@@ -0,0 +1,311 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestCompleteKNome(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		want float64
+	}{
+		{"zero", 0, 1.5707963267948966},
+		{"low", 0.3, 1.713889448178791},
+		{"half", 0.5, 1.8540746773013719},
+		{"logEdge", 0.9, 2.5780921133481733},
+		{"near", 0.99, 3.695637362989874},
+		{"nearer", 0.999, 4.841132560550297},
+		{"micro", 0.999999, 8.294051463601063},
+		{"nearest", 1 - 0x1p-40, 15.249237972322037},
+	}
+
+	t.Log("Given the need to evaluate K(m) through the nome close to m = 1.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking K(%v).", testID, test.m)
+				{
+					got := mathext.CompleteKNome(test.m)
+					if d := math.Abs(got - test.want); d > ulp(test.want) {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v : off by %v ulp.", failed, testID, test.want, got, d/ulp(test.want))
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v.", succeed, testID, test.want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestCompleteKNomeDomain(t *testing.T) {
+	t.Log("Given the need to handle the edges of the domain.")
+	{
+		t.Logf("\tTest 0:\tWhen checking m outside of [0, 1].")
+		{
+			for _, m := range []float64{-0.1, 1.1, math.NaN()} {
+				if got := mathext.CompleteKNome(m); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest 0:\tShould get NaN for m=%v, got %v.", failed, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get NaN.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking m = 1.")
+		{
+			if got := mathext.CompleteKNome(1); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest 1:\tShould get +Inf, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get +Inf.", succeed)
+		}
+	}
+}
+
+func TestNomePeriodRatio(t *testing.T) {
+	tt := []struct {
+		name  string
+		m     float64
+		ratio float64
+		q     float64
+	}{
+		{"tiny", 1e-10, 8.211898389388969, 6.2500000003125e-12},
+		{"tenth", 0.1, 1.598874970177602, 0.006584651553858371},
+		{"half", 0.5, 1, 0.04321391826377225},
+		{"high", 0.9, 0.6254397739986639, 0.14017312695426157},
+		{"near", 0.999, 0.3245499327423386, 0.3607378778859829},
+	}
+
+	t.Log("Given the need to compute the period ratio and the nome together.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking m=%v.", testID, test.m)
+				{
+					ratio := mathext.PeriodRatio(test.m)
+					if e := relErr(ratio, test.ratio); e > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get K'/K=%v, got %v : rel err %g.", failed, testID, test.ratio, ratio, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get K'/K=%v.", succeed, testID, test.ratio)
+
+					q := mathext.Nome(test.m)
+					if e := relErr(q, test.q); e > 1e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get q=%v, got %v : rel err %g.", failed, testID, test.q, q, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get q=%v.", succeed, testID, test.q)
+
+					if e := relErr(math.Exp(-math.Pi*ratio), q); e > 4e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould have q = exp(-π·K'/K), got %v and %v.", failed, testID, q, math.Exp(-math.Pi*ratio))
+					}
+					t.Logf("\t%s\tTest %d:\tShould have q = exp(-π·K'/K).", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen checking the limits at m = 0 and m = 1.", len(tt))
+		{
+			if r, q := mathext.PeriodRatio(0), mathext.Nome(0); !math.IsInf(r, 1) || q != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get +Inf and 0 at m=0, got %v and %v.", failed, len(tt), r, q)
+			}
+			if r, q := mathext.PeriodRatio(1), mathext.Nome(1); r != 0 || q != 1 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 0 and 1 at m=1, got %v and %v.", failed, len(tt), r, q)
+			}
+			t.Logf("\t%s\tTest %d:\tShould get the limits.", succeed, len(tt))
+		}
+	}
+}
+
+func TestComplementaryNome(t *testing.T) {
+	tt := []struct {
+		name string
+		m    float64
+		q1   float64
+	}{
+		{"tiny", 1e-10, 0.6821089089834577},
+		{"tenth", 0.1, 0.14017312695426154},
+		{"half", 0.5, 0.04321391826377225},
+		{"high", 0.9, 0.006584651553858368},
+	}
+
+	t.Log("Given the need to compute the complementary nome.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking m=%v.", testID, test.m)
+				{
+					q1 := mathext.ComplementaryNome(test.m)
+					if e := relErr(q1, test.q1); e > 2e-15 {
+						t.Fatalf("\t%s\tTest %d:\tShould get q1=%v, got %v : rel err %g.", failed, testID, test.q1, q1, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get q1=%v.", succeed, testID, test.q1)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen comparing with the nome of the complement.", len(tt))
+		{
+			for _, m := range []float64{0.125, 0.25, 0.375, 0.5, 0.6, 0.75, 0.9, 0.999} {
+				if q1, q := mathext.ComplementaryNome(m), mathext.Nome(1-m); q1 != q {
+					t.Fatalf("\t%s\tTest %d:\tShould get Nome(1-m)=%v at m=%v, got %v.", failed, len(tt), q, m, q1)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould equal Nome(1-m) when 1-m is exact.", succeed, len(tt))
+		}
+
+		t.Logf("\tTest %d:\tWhen driving the theta functions with either nome.", len(tt)+1)
+		{
+			for _, m := range []float64{0.1, 0.3, 0.5, 0.7, 0.9} {
+				q, q1 := mathext.Nome(m), mathext.ComplementaryNome(m)
+				if e := relErr(math.Log(q)*math.Log(q1), math.Pi*math.Pi); e > 1e-15 {
+					t.Fatalf("\t%s\tTest %d:\tShould have ln q·ln q1 = π² at m=%v : rel err %g.", failed, len(tt)+1, m, e)
+				}
+
+				t3, t31 := mathext.Theta3(0, q), mathext.Theta3(0, q1)
+				if e := relErr(t3*t3, 2*mathext.CompleteK(m)/math.Pi); e > 1e-15 {
+					t.Fatalf("\t%s\tTest %d:\tShould have θ₃(0, q)² = 2K/π at m=%v : rel err %g.", failed, len(tt)+1, m, e)
+				}
+				if e := relErr(t31*t31, 2*mathext.CompleteK(1-m)/math.Pi); e > 1e-15 {
+					t.Fatalf("\t%s\tTest %d:\tShould have θ₃(0, q1)² = 2K'/π at m=%v : rel err %g.", failed, len(tt)+1, m, e)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould give K and K' from the two nomes.", succeed, len(tt)+1)
+		}
+
+		t.Logf("\tTest %d:\tWhen checking the limits at m = 0 and m = 1.", len(tt)+2)
+		{
+			if q0, q1 := mathext.ComplementaryNome(0), mathext.ComplementaryNome(1); q0 != 1 || q1 != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 1 and 0, got %v and %v.", failed, len(tt)+2, q0, q1)
+			}
+			t.Logf("\t%s\tTest %d:\tShould get the limits.", succeed, len(tt)+2)
+		}
+	}
+}
+
+func TestNomeInverseValDeriv(t *testing.T) {
+	t.Log("Given the need to recover m and dm/dq from the nome.")
+	{
+		for testID, q := range []float64{1e-6, 0.01, 0.1, 0.3, 0.45} {
+			t.Logf("\tTest %d:\tWhen checking q=%v.", testID, q)
+			{
+				m, dmdq := mathext.NomeInverseValDeriv(q)
+
+				// Rounding m to float64 moves q by ulp(m)/(dm/dq).
+				if e := relErr(mathext.Nome(m), q); e > 1e-15+2*ulp(m)/(dmdq*q) {
+					t.Fatalf("\t%s\tTest %d:\tShould get back q from Nome(%v), got %v.", failed, testID, m, mathext.Nome(m))
+				}
+				t.Logf("\t%s\tTest %d:\tShould invert Nome.", succeed, testID)
+
+				// Above q ≈ 0.5, m is so close to 1 that float64 cannot
+				// resolve a finite difference.
+				h := 1e-4 * q
+				mp, _ := mathext.NomeInverseValDeriv(q + h)
+				mm, _ := mathext.NomeInverseValDeriv(q - h)
+				if e := relErr(dmdq, (mp-mm)/(2*h)); e > 1e-6 {
+					t.Fatalf("\t%s\tTest %d:\tShould get about %v, got %v : rel err %g.", failed, testID, (mp-mm)/(2*h), dmdq, e)
+				}
+				t.Logf("\t%s\tTest %d:\tShould match the central difference.", succeed, testID)
+			}
+		}
+
+		t.Logf("\tTest 5:\tWhen checking q = 0.")
+		{
+			if m, dmdq := mathext.NomeInverseValDeriv(0); m != 0 || dmdq != 16 {
+				t.Fatalf("\t%s\tTest 5:\tShould get 0 and 16, got %v and %v.", failed, m, dmdq)
+			}
+			t.Logf("\t%s\tTest 5:\tShould get m = 0 and dm/dq = 16.", succeed)
+		}
+	}
+}
+
+func TestNomeInverse(t *testing.T) {
+	t.Log("Given the need to recover m from the nome alone.")
+	{
+		t.Logf("\tTest 0:\tWhen inverting Nome over the whole range of m.")
+		{
+			for _, m := range []float64{1e-12, 1e-4, 0.1, 0.3, 0.5, 0.7, 0.9, 0.999, 0.999999} {
+				if got := mathext.NomeInverse(mathext.Nome(m)); relErr(got, m) > 1e-14 {
+					t.Fatalf("\t%s\tTest 0:\tShould get back m=%v, got %v.", failed, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get back m.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen inverting NomeInverse while m is not rounded next to 1.")
+		{
+			for _, q := range []float64{1e-6, 0.01, 0.04, 0.05, 0.1, 0.2} {
+				m := mathext.NomeInverse(q)
+				if got := mathext.Nome(m); relErr(got, q) > 1e-14 {
+					t.Fatalf("\t%s\tTest 1:\tShould get back q=%v from m=%v, got %v.", failed, q, m, got)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get back q.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen comparing with high precision values above q = e^-π.")
+		{
+			for _, test := range [][2]float64{{0.05, 0.5518703454669689}, {0.2, 0.965852193595079}, {0.5, 0.9999895221373104}} {
+				if got := mathext.NomeInverse(test[0]); relErr(got, test[1]) > 1e-15 {
+					t.Fatalf("\t%s\tTest 2:\tShould get m=%v at q=%v, got %v.", failed, test[1], test[0], got)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould match to a few ulp.", succeed)
+		}
+
+		t.Logf("\tTest 3:\tWhen q is at the ends of or outside of [0, 1].")
+		{
+			if mathext.NomeInverse(0) != 0 || mathext.NomeInverse(1) != 1 {
+				t.Fatalf("\t%s\tTest 3:\tShould get 0 and 1.", failed)
+			}
+			for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+				if got := mathext.NomeInverse(q); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest 3:\tShould get NaN at q=%v, got %v.", failed, q, got)
+				}
+			}
+			t.Logf("\t%s\tTest 3:\tShould get 0, 1 and NaN.", succeed)
+		}
+	}
+}
+
+func TestParameterFromPeriods(t *testing.T) {
+	t.Log("Given the need to recover m from the half-periods of a lattice.")
+	{
+		t.Logf("\tTest 0:\tWhen round tripping m through K(m) and K(1-m).")
+		{
+			for i := 1; i < 80; i++ {
+				m := 0.1 + float64(i)*0.01
+				k, kc := mathext.CompleteK(m), mathext.CompleteK(1-m)
+				scale := math.Ldexp(1, i%7-3)
+
+				// A relative error ε in ω'/ω moves m by 4m(1-m)KK'/π·ε, which
+				// is up to 6ε in this range.
+				tol := 2 * (ulp(m) + 4*m*(1-m)*k*kc/math.Pi*0x1p-52)
+				gm, gk := mathext.ParameterFromPeriods(scale*k, scale*kc)
+				if d := math.Abs(gm - m); d > tol {
+					t.Fatalf("\t%s\tTest 0:\tShould recover m=%v, got %v : off by %v ulp.", failed, m, gm, d/ulp(m))
+				}
+				if e := relErr(gk, k); e > 1e-15 {
+					t.Fatalf("\t%s\tTest 0:\tShould get K(%v), got %v : rel err %g.", failed, m, gk, e)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould recover m to a few ulp of its condition number.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen a period is infinite or zero.")
+		{
+			if m, k := mathext.ParameterFromPeriods(1, math.Inf(1)); m != 0 || k != math.Pi/2 {
+				t.Fatalf("\t%s\tTest 1:\tShould get m = 0, got %v and %v.", failed, m, k)
+			}
+			if m, k := mathext.ParameterFromPeriods(1, 0); m != 1 || !math.IsInf(k, 1) {
+				t.Fatalf("\t%s\tTest 1:\tShould get m = 1, got %v and %v.", failed, m, k)
+			}
+			if m, _ := mathext.ParameterFromPeriods(0, 1); !math.IsNaN(m) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN for a zero real period, got %v.", failed, m)
+			}
+			t.Logf("\t%s\tTest 1:\tShould handle the degenerate lattices.", succeed)
+		}
+	}
+}
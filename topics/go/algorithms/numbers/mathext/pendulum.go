@@ -0,0 +1,77 @@
+package mathext
+
+import "math"
+
+// PendulumParameterFromEnergy maps the total energy of a simple pendulum,
+// measured from the bottom of its swing, to the parameter of the elliptic
+// integrals that describe its motion. mgl is the product of mass, gravity
+// and length, so 2·mgl is the energy needed to reach the top.
+//
+// Below the separatrix the pendulum oscillates between the turning angles
+// ±θ₀ and
+//
+//	m = E/(2mgl) = sin²(θ₀/2)
+//
+// is returned with ok set to true. At or above the separatrix the
+// pendulum rotates, the same ratio is returned with ok set to false and
+// the motion is described by PendulumRotationPeriod instead. energy must
+// be non-negative and mgl positive.
+func PendulumParameterFromEnergy(energy, mgl float64) (m float64, ok bool) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(energy) || math.IsNaN(mgl) || energy < 0 || mgl <= 0 || math.IsInf(mgl, 1) {
+		return math.NaN(), false
+	}
+
+	m = energy / (2 * mgl)
+	return m, m < 1
+}
+
+// PendulumPeriod computes the period of an oscillating pendulum,
+//
+//	T = 4K(m)/ω₀
+//
+// where m is the parameter returned by PendulumParameterFromEnergy and
+// ω₀ = √(g/l) is the angular frequency of small oscillations. It reduces
+// to 2π/ω₀ at m = 0 and grows without bound towards the separatrix at
+// m = 1, where +Inf is returned. m must be in [0, 1] and ω₀ positive.
+func PendulumPeriod(m, omega0 float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(m) || math.IsNaN(omega0) || m < 0 || m > 1 || omega0 <= 0 {
+		return math.NaN()
+	}
+
+	return 4 * completeK(m, 1-m) / omega0
+}
+
+// PendulumRotationPeriod computes the time a pendulum with total energy E
+// above the separatrix takes for one full turn. With m = 2mgl/E and
+// ω₀ = √(g/l) the angular velocity is θ' = 2ω₀/√m·√(1 - m sin²(θ/2)),
+// and integrating over a turn gives
+//
+//	T = 2√m·K(m)/ω₀
+//
+// which falls to the free rotation time π√m/ω₀ at high energy. The
+// complement 1 - m is formed as (E - 2mgl)/E so the period keeps its
+// precision close to the separatrix, where it diverges and +Inf is
+// returned. An infinite energy gives 0. Energies below 2mgl oscillate
+// instead and give NaN, see PendulumPeriod. mgl and ω₀ must be positive.
+func PendulumRotationPeriod(energy, mgl, omega0 float64) float64 {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(energy) || math.IsNaN(mgl) || math.IsNaN(omega0) || mgl <= 0 || math.IsInf(mgl, 1) || omega0 <= 0 || energy < 2*mgl {
+		return math.NaN()
+	}
+
+	top := 2 * mgl
+	switch {
+	case energy == top:
+		return math.Inf(1)
+	case math.IsInf(energy, 1):
+		return 0
+	}
+
+	m, mc := top/energy, (energy-top)/energy
+	return 2 * math.Sqrt(m) * completeK(m, mc) / omega0
+}
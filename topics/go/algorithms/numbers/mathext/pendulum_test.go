@@ -0,0 +1,116 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestPendulumParameterFromEnergy(t *testing.T) {
+	t.Log("Given the need to go from the energy of a pendulum to its period.")
+	{
+		t.Logf("\tTest 0:\tWhen swinging to ±60°.")
+		{
+
+			// A 2 m pendulum released at θ₀ = π/3 has E = mgl(1 - cos θ₀)
+			// and m = sin²(π/6) = 1/4.
+			const mgl, omega0 = 3.5, 2.2147234590350102
+			m, ok := mathext.PendulumParameterFromEnergy(mgl*(1-math.Cos(math.Pi/3)), mgl)
+			if !ok || relErr(m, 0.25) > 1e-15 {
+				t.Fatalf("\t%s\tTest 0:\tShould get m = 1/4 for an oscillation, got %v %v.", failed, m, ok)
+			}
+			if got := mathext.PendulumPeriod(m, omega0); relErr(got, 3.0446245519918842) > 4e-15 {
+				t.Fatalf("\t%s\tTest 0:\tShould get a period of 3.0446245519918842 s, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get m = 1/4 and a period of 3.0446245519918842 s.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen crossing the separatrix.")
+		{
+			const mgl = 1.5
+			below := math.Nextafter(2*mgl, 0)
+			if m, ok := mathext.PendulumParameterFromEnergy(below, mgl); !ok || m >= 1 {
+				t.Fatalf("\t%s\tTest 1:\tShould oscillate just below 2mgl, got %v %v.", failed, m, ok)
+			}
+			if m, ok := mathext.PendulumParameterFromEnergy(2*mgl, mgl); ok || m != 1 {
+				t.Fatalf("\t%s\tTest 1:\tShould not oscillate at 2mgl, got %v %v.", failed, m, ok)
+			}
+			if m, ok := mathext.PendulumParameterFromEnergy(3*mgl, mgl); ok || m != 1.5 {
+				t.Fatalf("\t%s\tTest 1:\tShould rotate above 2mgl, got %v %v.", failed, m, ok)
+			}
+			if got := mathext.PendulumPeriod(1, 1); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest 1:\tShould get an infinite period on the separatrix, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould switch from oscillation to rotation at 2mgl.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking small oscillations and bad input.")
+		{
+			if got := mathext.PendulumPeriod(0, 2); relErr(got, math.Pi) > 4e-16 {
+				t.Fatalf("\t%s\tTest 2:\tShould get 2π/ω₀ at m = 0, got %v.", failed, got)
+			}
+			if m, ok := mathext.PendulumParameterFromEnergy(-1, 1); ok || !math.IsNaN(m) {
+				t.Fatalf("\t%s\tTest 2:\tShould reject a negative energy, got %v %v.", failed, m, ok)
+			}
+			if m, ok := mathext.PendulumParameterFromEnergy(1, 0); ok || !math.IsNaN(m) {
+				t.Fatalf("\t%s\tTest 2:\tShould reject mgl = 0, got %v %v.", failed, m, ok)
+			}
+			t.Logf("\t%s\tTest 2:\tShould get 2π/ω₀ and reject bad input.", succeed)
+		}
+	}
+}
+
+func TestPendulumRotationPeriod(t *testing.T) {
+	t.Log("Given the need to compute the period of a rotating pendulum.")
+	{
+		t.Logf("\tTest 0:\tWhen rotating with twice the energy needed to reach the top.")
+		{
+			if got := mathext.PendulumRotationPeriod(4, 1.5, 1.25); relErr(got, 2.9881557350293419) > 4e-15 {
+				t.Fatalf("\t%s\tTest 0:\tShould get 2.9881557350293419, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get 2.9881557350293419.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen approaching the separatrix from both sides.")
+		{
+
+			// Near the separatrix a rotation passes the top once and an
+			// oscillation twice, so both periods diverge like ln(1/ε) and
+			// the oscillation period tends to twice the rotation period.
+			const mgl, omega0 = 1.0, 1.0
+			last := 0.0
+			for _, eps := range []float64{0x1p-7, 0x1p-14, 0x1p-27, 0x1p-40} {
+				m, _ := mathext.PendulumParameterFromEnergy(2*mgl*(1-eps), mgl)
+				osc := mathext.PendulumPeriod(m, omega0)
+				rot := mathext.PendulumRotationPeriod(2*mgl*(1+eps), mgl, omega0)
+				if rot <= last || osc <= 2*last {
+					t.Fatalf("\t%s\tTest 1:\tShould grow as ε=%v shrinks, got %v and %v.", failed, eps, osc, rot)
+				}
+				if d := math.Abs(osc - 2*rot); d > 20*eps*math.Log(1/eps)+1e-10 {
+					t.Fatalf("\t%s\tTest 1:\tShould join the two regimes at ε=%v, got %v and %v.", failed, eps, osc, rot)
+				}
+				last = rot
+			}
+			if got := mathext.PendulumRotationPeriod(2*mgl, mgl, omega0); !math.IsInf(got, 1) {
+				t.Fatalf("\t%s\tTest 1:\tShould get +Inf on the separatrix, got %v.", failed, got)
+			}
+			if got := mathext.PendulumRotationPeriod(math.Nextafter(2*mgl, 0), mgl, omega0); !math.IsNaN(got) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN below the separatrix, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould diverge from both sides and join the regimes.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen the energy is large.")
+		{
+			const energy, mgl = 1e12, 1.0
+			if got, want := mathext.PendulumRotationPeriod(energy, mgl, 1), math.Pi*math.Sqrt(2*mgl/energy); relErr(got, want) > 1e-11 {
+				t.Fatalf("\t%s\tTest 2:\tShould approach the free rotation time %v, got %v.", failed, want, got)
+			}
+			if got := mathext.PendulumRotationPeriod(math.Inf(1), mgl, 1); got != 0 {
+				t.Fatalf("\t%s\tTest 2:\tShould get 0 for an infinite energy, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 2:\tShould approach the free rotation time.", succeed)
+		}
+	}
+}
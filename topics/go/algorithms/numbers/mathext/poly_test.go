@@ -0,0 +1,48 @@
+package mathext
+
+import "testing"
+
+var poly float64
+
+// benchBranches are the Taylor branches timed by the polynomial benchmarks.
+var benchBranches = []struct {
+	name   string
+	branch *taylorBranch
+}{
+	{"K0.05", &kTaylor[0]},
+	{"K0.45", &kTaylor[4]},
+	{"K0.875", &kTaylor[9]},
+	{"E0.75", &eTaylor[7]},
+}
+
+// BenchmarkHorner times the branches with Horner's rule, which is how
+// they were evaluated before the switch to the Estrin scheme.
+func BenchmarkHorner(b *testing.B) {
+	for _, bb := range benchBranches {
+		br := bb.branch
+		x := (br.upper - br.center) / 3
+		b.Run(bb.name, func(b *testing.B) {
+			v := x
+			for i := 0; i < b.N; i++ {
+				v = horner(x+0*v, br.coeffs)
+			}
+			poly = v
+		})
+	}
+}
+
+// BenchmarkEstrin times the branches the way CompleteK and CompleteE
+// evaluate them.
+func BenchmarkEstrin(b *testing.B) {
+	for _, bb := range benchBranches {
+		br := bb.branch
+		m := br.center + (br.upper-br.center)/3
+		b.Run(bb.name, func(b *testing.B) {
+			v := m
+			for i := 0; i < b.N; i++ {
+				v = br.eval(m + 0*v)
+			}
+			poly = v
+		})
+	}
+}
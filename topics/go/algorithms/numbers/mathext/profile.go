@@ -0,0 +1,115 @@
+package mathext
+
+import (
+	"math"
+	"math/big"
+	"runtime"
+	"time"
+)
+
+// profilePrec is the precision in bits of the reference values used by
+// ProfileBackends, enough to make their own error irrelevant.
+const profilePrec = 128
+
+// profileMinTime is how long ProfileBackends times every backend. The
+// samples are repeated until it has passed so that short inputs still
+// give a stable per-call time.
+const profileMinTime = 10 * time.Millisecond
+
+// BackendStats describes the accuracy and the speed of one implementation
+// of K(m) over a set of samples.
+type BackendStats struct {
+	Name      string
+	MaxRelErr float64
+	NsPerCall float64
+}
+
+// ProfileBackends compares the implementations of K(m) on the parameters
+// in m. The backends are
+//
+//	Table    the piecewise Taylor tables behind CompleteK
+//	Carlson  RF(0, 1-m, 1) from the duplication theorem
+//	AGM      π/(2·AGM(1, √(1-m))) as in CompleteKAGM
+//
+// MaxRelErr is measured against the AGM carried out with big.Float at 128
+// bits and NsPerCall is the wall time of a call averaged over repeated
+// passes through m. Samples outside of [0, 1) are skipped and nil is
+// returned when none are left. It is meant for choosing a backend, not
+// for use on a hot path, and takes a few tens of milliseconds.
+func ProfileBackends(m []float64) []BackendStats {
+	var samples, ref []float64
+	for _, v := range m {
+		if v >= 0 && v < 1 {
+			samples = append(samples, v)
+			ref = append(ref, completeKBig(v))
+		}
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+
+	backends := []struct {
+		name string
+		k    func(m float64) float64
+	}{
+		{"Table", CompleteK},
+		{"Carlson", func(m float64) float64 { return rf(0, 1-m, 1) }},
+		{"AGM", func(m float64) float64 {
+			k, _ := CompleteKAGM(m, agmMaxIter)
+			return k
+		}},
+	}
+
+	stats := make([]BackendStats, len(backends))
+	for i, b := range backends {
+		stats[i].Name = b.name
+		for j, v := range samples {
+			if e := math.Abs(b.k(v)-ref[j]) / ref[j]; e > stats[i].MaxRelErr {
+				stats[i].MaxRelErr = e
+			}
+		}
+
+		// The sum is kept alive so that the timed calls are not optimized
+		// away. It stays local so that concurrent calls do not race.
+		var calls int
+		var sum float64
+		start := time.Now()
+		for time.Since(start) < profileMinTime {
+			for _, v := range samples {
+				sum += b.k(v)
+			}
+			calls += len(samples)
+		}
+		stats[i].NsPerCall = float64(time.Since(start).Nanoseconds()) / float64(calls)
+		runtime.KeepAlive(sum)
+	}
+
+	return stats
+}
+
+// completeKBig computes K(m) = π/(2·AGM(1, √(1-m))) in big.Float
+// arithmetic at profilePrec bits and rounds it to float64.
+func completeKBig(m float64) float64 {
+	newFloat := func() *big.Float { return new(big.Float).SetPrec(profilePrec) }
+
+	a := newFloat().SetInt64(1)
+	b := newFloat().Sub(a, newFloat().SetFloat64(m))
+	b.Sqrt(b)
+
+	// Stop once the means agree to the working precision.
+	eps := newFloat().SetMantExp(newFloat().SetInt64(1), -profilePrec+4)
+	for {
+		d := newFloat().Sub(a, b)
+		if d.Abs(d).Cmp(newFloat().Mul(eps, a)) <= 0 {
+			break
+		}
+		an := newFloat().Add(a, b)
+		an.Quo(an, newFloat().SetInt64(2))
+		b.Sqrt(b.Mul(a, b))
+		a = an
+	}
+
+	pi, _, _ := big.ParseFloat("3.14159265358979323846264338327950288419716939937510582", 10, profilePrec, big.ToNearestEven)
+	k, _ := newFloat().Quo(pi, a.Add(a, b)).Float64()
+	return k
+}
@@ -0,0 +1,59 @@
+package mathext_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestProfileBackends(t *testing.T) {
+	t.Log("Given the need to compare the implementations of K(m).")
+	{
+		t.Logf("\tTest 0:\tWhen profiling a few samples.")
+		{
+			stats := mathext.ProfileBackends([]float64{0, 0.1, 0.5, 0.9, 0.999, 1 - 1e-12, 1, -1})
+			if len(stats) != 3 {
+				t.Fatalf("\t%s\tTest 0:\tShould get three backends, got %d.", failed, len(stats))
+			}
+
+			names := map[string]bool{}
+			for _, s := range stats {
+				names[s.Name] = true
+				if !(s.MaxRelErr >= 0 && s.MaxRelErr < 1e-15) {
+					t.Fatalf("\t%s\tTest 0:\tShould get a relative error of a few ulp for %s, got %g.", failed, s.Name, s.MaxRelErr)
+				}
+				if !(s.NsPerCall > 0 && s.NsPerCall < 1e6) {
+					t.Fatalf("\t%s\tTest 0:\tShould get a positive time per call for %s, got %v.", failed, s.Name, s.NsPerCall)
+				}
+				t.Logf("\t\t%-8s max rel err %.3g, %.1f ns/call", s.Name, s.MaxRelErr, s.NsPerCall)
+			}
+			if !names["Table"] || !names["Carlson"] || !names["AGM"] {
+				t.Fatalf("\t%s\tTest 0:\tShould get the Table, Carlson and AGM backends, got %v.", failed, stats)
+			}
+			t.Logf("\t%s\tTest 0:\tShould fill in the stats of every backend.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen no sample is in the domain.")
+		{
+			if stats := mathext.ProfileBackends([]float64{1, 2}); stats != nil {
+				t.Fatalf("\t%s\tTest 1:\tShould get nil, got %v.", failed, stats)
+			}
+			t.Logf("\t%s\tTest 1:\tShould get nil.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen profiling from several goroutines.")
+		{
+			var wg sync.WaitGroup
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					mathext.ProfileBackends([]float64{0.5})
+				}()
+			}
+			wg.Wait()
+			t.Logf("\t%s\tTest 2:\tShould not share state between calls.", succeed)
+		}
+	}
+}
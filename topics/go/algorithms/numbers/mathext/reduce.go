@@ -0,0 +1,539 @@
+package mathext
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// rootsMaxIter bounds the Aberth iteration in polyRoots. It converges
+// cubically to simple roots, so this is only reached next to multiple
+// roots, which then come out with about half of the digits.
+const rootsMaxIter = 200
+
+// rootImagTol is the imaginary part, relative to the modulus, below which
+// a root found by polyRoots is taken to be real. It is loose enough to
+// accept the half precision roots next to a double root.
+const rootImagTol = 1e-6
+
+// QuarticTerm is the term Coeff·(t - Pole)ⁿ, n = Power, of the rational
+// factor R(t) in ReduceQuarticIntegral. A negative Power is a pole of that
+// order at Pole. For a non-negative Power, Pole is only the point about
+// which the polynomial term is written, so t² is {1, 0, 2}.
+type QuarticTerm struct {
+	Coeff float64
+	Pole  float64
+	Power int
+}
+
+// ReduceQuarticIntegral computes the general elliptic integral
+//
+//	∫ R(t) dt / √p(t)  from lower to upper,  p(t) = Σ coeffs[i]·tⁱ
+//
+// for a cubic or quartic p with real roots, where R(t) is the sum of the
+// terms, or 1 when there are none. Every ∫ R(t, √p(t)) dt splits into an
+// integral of this form and one of a rational function of t alone, and
+// the partial fractions of R give the terms.
+//
+// The integral is reduced to Carlson's symmetric integrals. With p(t) =
+// c·Π (aᵢ + bᵢt) positive on the interval, Xᵢ = √(aᵢ + bᵢx) and Yᵢ =
+// √(aᵢ + bᵢy) at the limits x < y and a₄ = 1, b₄ = 0 for a cubic,
+//
+//	∫ₓʸ dt / √p(t) = 2/√c·RF(U₁₂², U₁₃², U₁₄²)
+//	U₁ⱼ = (X₁XⱼYₖYₗ + Y₁YⱼXₖXₗ) / (y - x),  {j, k, l} = {2, 3, 4}
+//
+// ∫ t dt/√p(t) follows from RD and ∫ dt/((t - s)√p(t)) from RJ and RC.
+// Differentiating (t - s)ⁿ√p(t) gives a recurrence that takes every other
+// power of t - s to these three. A quartic is first mapped to a cubic by
+// t = r + 1/u at the root r farthest from the interval.
+//
+// The formulas hold with roots at the limits, where the integrand has an
+// integrable singularity. A limit or pole at which p is exactly zero is
+// used as the root itself. The limits must be finite and the result is
+// negative when upper < lower.
+//
+// An error is returned when p has degree below 3, a pair of complex roots,
+// a root strictly between the limits or is negative on the interval, and
+// when a pole lies on the closed interval.
+//
+// Reference:
+// NIST Digital Library of Mathematical Functions, §19.29(i), reduction
+// of general elliptic integrals to symmetric integrals.
+func ReduceQuarticIntegral(coeffs [5]float64, lower, upper float64, terms ...QuarticTerm) (float64, error) {
+
+	// Reject arguments outside of the domain.
+	for _, c := range coeffs {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return math.NaN(), errors.New("mathext: coefficients must be finite")
+		}
+	}
+	if math.IsNaN(lower) || math.IsNaN(upper) || math.IsInf(lower, 0) || math.IsInf(upper, 0) {
+		return math.NaN(), errors.New("mathext: limits must be finite")
+	}
+	for _, term := range terms {
+		if math.IsNaN(term.Coeff) || math.IsInf(term.Coeff, 0) || math.IsNaN(term.Pole) || math.IsInf(term.Pole, 0) {
+			return math.NaN(), errors.New("mathext: terms must be finite")
+		}
+	}
+
+	n := 4
+	if coeffs[4] == 0 {
+		n = 3
+	}
+	if coeffs[n] == 0 {
+		return math.NaN(), errors.New("mathext: polynomial must be a cubic or a quartic")
+	}
+	if lower == upper {
+		return 0, nil
+	}
+
+	sign := 1.0
+	x, y := lower, upper
+	if x > y {
+		x, y, sign = y, x, -1
+	}
+
+	for _, term := range terms {
+		if term.Power < 0 && term.Pole >= x && term.Pole <= y {
+			return math.NaN(), errors.New("mathext: pole on the interval")
+		}
+	}
+	if len(terms) == 0 {
+		terms = []QuarticTerm{{Coeff: 1}}
+	}
+
+	roots := make([]float64, n)
+	for i, z := range polyRoots(coeffs[:n+1]) {
+		if math.Abs(imag(z)) > rootImagTol*math.Max(1, cmplx.Abs(z)) {
+			return math.NaN(), errors.New("mathext: polynomial has complex roots")
+		}
+		roots[i] = polishRoot(coeffs[:n+1], real(z))
+	}
+
+	// A limit or pole that is a root is taken exactly. The factors enter
+	// through their square roots, so a root that is off by an ulp would
+	// move the result by the square root of the rounding error.
+	snap := func(v float64) {
+		if p, _ := polyEval(coeffs[:n+1], complex(v, 0)); p == 0 {
+			near := 0
+			for i, r := range roots {
+				if math.Abs(r-v) < math.Abs(roots[near]-v) {
+					near = i
+				}
+			}
+			roots[near] = v
+		}
+	}
+	snap(x)
+	snap(y)
+	for _, term := range terms {
+		if term.Power < 0 {
+			snap(term.Pole)
+		}
+	}
+	rootIndex := func(v float64, roots []float64) int {
+		for i, r := range roots {
+			if r == v {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var sum float64
+	if n == 3 {
+		var g, h, xv, yv [3]float64
+		for i, r := range roots {
+			g[i], h[i] = -r, 1
+			xv[i], yv[i] = x-r, y-r
+		}
+		ec, err := newEllipticCubic(coeffs[3], g, h, x, y, xv, yv)
+		if err != nil {
+			return math.NaN(), err
+		}
+		for _, term := range terms {
+			root := -1
+			if term.Power < 0 {
+				root = rootIndex(term.Pole, roots)
+			}
+			sum += ec.integrate(term.Pole, root, map[int]float64{term.Power: term.Coeff})
+		}
+		return sign * sum, nil
+	}
+
+	// Map the quartic to the cubic c·Π (1 + (r - rᵢ)u) over the other
+	// roots with t = r + 1/u. The factors at the limits are written as
+	// (t - rᵢ)/(t - r), which is exactly zero at a root.
+	far := 0
+	dist := func(r float64) float64 { return math.Min(math.Abs(r-x), math.Abs(r-y)) }
+	for i, r := range roots {
+		if dist(r) > dist(roots[far]) {
+			far = i
+		}
+	}
+	r := roots[far]
+	others := make([]float64, 0, 3)
+	for i, ri := range roots {
+		if i != far {
+			others = append(others, ri)
+		}
+	}
+	if dist(r) == 0 || (r > x && r < y) {
+		return math.NaN(), errors.New("mathext: polynomial has a root inside of the interval")
+	}
+
+	var g, h, lo, hi [3]float64
+	for i, ri := range others {
+		g[i], h[i] = 1, r-ri
+		lo[i], hi[i] = (y-ri)/(y-r), (x-ri)/(x-r)
+	}
+	ec, err := newEllipticCubic(coeffs[4], g, h, 1/(y-r), 1/(x-r), lo, hi)
+	if err != nil {
+		return math.NaN(), err
+	}
+
+	for _, term := range terms {
+		s, pow := term.Pole, term.Power
+		laurent := make(map[int]float64)
+		switch {
+
+		// (t - s)ⁿ = Σ C(n, i)·(r - s)ⁱ·u^(i-n).
+		case pow >= 0:
+			f := term.Coeff
+			for i := 0; i <= pow; i++ {
+				laurent[i-pow] += f
+				f *= (r - s) * float64(pow-i) / float64(i+1)
+			}
+			sum += ec.integrate(0, -1, laurent)
+
+		// (t - r)⁻ʲ = uʲ.
+		case s == r:
+			laurent[-pow] = term.Coeff
+			sum += ec.integrate(0, -1, laurent)
+
+		// (t - s)⁻ʲ = (r - s)⁻ʲ·Σ C(j, i)·uₛ^(j-i)·(u - uₛ)^(i-j), uₛ =
+		// 1/(s - r).
+		default:
+			j := -pow
+			us := 1 / (s - r)
+			f := term.Coeff / math.Pow(r-s, float64(j)) * math.Pow(us, float64(j))
+			for i := 0; i <= j; i++ {
+				laurent[i-j] += f
+				f *= float64(j-i) / float64(i+1) / us
+			}
+			sum += ec.integrate(us, rootIndex(s, others), laurent)
+		}
+	}
+
+	return sign * sum, nil
+}
+
+// ellipticCubic integrates Laurent polynomials against 1/√P(v) over
+// [lo, hi] for the cubic P(v) = c·Π (gᵢ + hᵢv) with real roots, none of
+// them inside of the interval.
+type ellipticCubic struct {
+	c      float64
+	g, h   [3]float64
+	lo, hi float64
+
+	// The factors written as aᵢ + bᵢv, non-negative on the interval, with
+	// a fourth factor 1, the square roots of them at the limits and the
+	// scale 1/√c' of P = c'·Π (aᵢ + bᵢv).
+	a, b   [4]float64
+	xs, ys [4]float64
+	scale  float64
+
+	// The squares of U₁₂, U₁₃ and U₁₄, ∫ dv/√Π (aᵢ + bᵢv) and the moments
+	// ∫ dv/√P and ∫ v dv/√P.
+	u      [4][4]float64
+	i1     float64
+	j0, j1 float64
+}
+
+// newEllipticCubic prepares the reduction over [lo, hi]. xv and yv are
+// the factors gᵢ + hᵢv at lo and hi, which the caller may compute more
+// accurately than from g and h.
+func newEllipticCubic(c float64, g, h [3]float64, lo, hi float64, xv, yv [3]float64) (*ellipticCubic, error) {
+	ec := ellipticCubic{c: c, g: g, h: h, lo: lo, hi: hi}
+
+	// Write every factor with the sign it has on the interval.
+	lead := c
+	for i := range g {
+		if xv[i]*yv[i] < 0 {
+			return nil, errors.New("mathext: polynomial has a root inside of the interval")
+		}
+		sigma := 1.0
+		if xv[i] < 0 || yv[i] < 0 {
+			sigma, lead = -1, -lead
+		}
+		ec.a[i], ec.b[i] = sigma*g[i], sigma*h[i]
+		ec.xs[i], ec.ys[i] = math.Sqrt(sigma*xv[i]), math.Sqrt(sigma*yv[i])
+	}
+	if lead < 0 {
+		return nil, errors.New("mathext: polynomial is negative on the interval")
+	}
+	ec.a[3], ec.xs[3], ec.ys[3] = 1, 1, 1
+	ec.scale = 1 / math.Sqrt(lead)
+
+	// Uᵢⱼ = Uₖₗ for {i, j, k, l} = {1, 2, 3, 4}.
+	d := hi - lo
+	for i := 0; i < 4; i++ {
+		for j := i + 1; j < 4; j++ {
+			k, l := ec.rest(i, j)
+			u := (ec.xs[i]*ec.xs[j]*ec.ys[k]*ec.ys[l] + ec.ys[i]*ec.ys[j]*ec.xs[k]*ec.xs[l]) / d
+			ec.u[i][j], ec.u[j][i] = u*u, u*u
+		}
+	}
+	ec.i1 = 2 * rf(ec.u[0][1], ec.u[0][2], ec.u[0][3])
+	ec.j0 = ec.scale * ec.i1
+
+	// ∫ (a₁ + b₁v) dv/√Π = 2/3·d₁₂d₁₃·RD(U₁₂², U₁₃², U₁₄²) + 2X₁Y₁/U₁₄
+	// with the fourth factor 1, for the factor with the largest slope.
+	f := 0
+	for i := range g {
+		if math.Abs(ec.b[i]) > math.Abs(ec.b[f]) {
+			f = i
+		}
+	}
+	k, l := ec.rest(f, 3)
+	i2 := 2.0/3*ec.d(f, k)*ec.d(f, l)*rd(ec.u[f][k], ec.u[f][l], ec.u[f][3]) + 2*ec.xs[f]*ec.ys[f]/math.Sqrt(ec.u[f][3])
+	ec.j1 = ec.scale * (i2 - ec.a[f]*ec.i1) / ec.b[f]
+
+	return &ec, nil
+}
+
+// rest returns the two factor indices other than i and j.
+func (ec *ellipticCubic) rest(i, j int) (k, l int) {
+	k, l = -1, -1
+	for n := 0; n < 4; n++ {
+		switch {
+		case n == i || n == j:
+		case k < 0:
+			k = n
+		default:
+			l = n
+		}
+	}
+	return k, l
+}
+
+// d returns dᵢⱼ = aᵢbⱼ - aⱼbᵢ.
+func (ec *ellipticCubic) d(i, j int) float64 {
+	return ec.a[i]*ec.b[j] - ec.a[j]*ec.b[i]
+}
+
+// sqrtP returns √P at lo and hi.
+func (ec *ellipticCubic) sqrtP() (lo, hi float64) {
+	lo, hi = 1/ec.scale, 1/ec.scale
+	for i := 0; i < 3; i++ {
+		lo *= ec.xs[i]
+		hi *= ec.ys[i]
+	}
+	return lo, hi
+}
+
+// pole returns ∫ dv/((v - s)√P) for s outside of the interval and not a
+// root, from
+//
+//	∫ (a₁ + b₁v)/(a₅ + b₅v) dv/√Π = 2/3·d₁₂d₁₃d₁₄/d₁₅·RJ(U₁₂², U₁₃², U₁₄², W²)
+//	                               + 2·RC(P², Q²)
+//	W² = U₁₂² - d₁₃d₁₄d₂₅/d₁₅,  Q² = (X₅Y₅/(X₁Y₁))²·W²
+//	P² = Q² + d₂₅d₃₅d₄₅/d₁₅
+//
+// with a₅ + b₅v = ±(v - s) positive on the interval and a first factor
+// that is not zero at either limit.
+func (ec *ellipticCubic) pole(s float64) float64 {
+	a5, b5, sign := s, -1.0, -1.0
+	if s < ec.lo {
+		a5, b5, sign = -s, 1, 1
+	}
+	x5, y5 := math.Sqrt(a5+b5*ec.lo), math.Sqrt(a5+b5*ec.hi)
+
+	i := 0
+	for ec.xs[i]*ec.ys[i] == 0 {
+		i++
+	}
+	j := (i + 1) % 4
+	k, l := ec.rest(i, j)
+	d5 := func(n int) float64 { return ec.a[n]*b5 - a5*ec.b[n] }
+
+	w2 := ec.u[i][j] - ec.d(i, k)*ec.d(i, l)*d5(j)/d5(i)
+	q := x5 * y5 / (ec.xs[i] * ec.ys[i])
+	q2 := q * q * w2
+	p2 := q2 + d5(j)*d5(k)*d5(l)/d5(i)
+	i3 := 2.0/3*ec.d(i, j)*ec.d(i, k)*ec.d(i, l)/d5(i)*rj(ec.u[i][j], ec.u[i][k], ec.u[i][l], w2) + 2*rc(p2, q2)
+
+	return sign * ec.scale * (b5*i3 - ec.b[i]*ec.i1) / d5(i)
+}
+
+// integrate returns ∫ Σ lᵢ·(v - s)ⁱ dv/√P over the interval for the
+// Laurent coefficients lᵢ about s, which must not be inside of the
+// interval. root is the index of the factor that is zero at s, or -1.
+//
+// With P = Σ pₖ·(v - s)ᵏ, the derivative of (v - s)ⁿ√P gives
+//
+//	[(v - s)ⁿ√P] = Σ (n + k/2)·pₖ·Lₙ₋₁₊ₖ,  Lᵢ = ∫ (v - s)ⁱ dv/√P
+//
+// which takes every power above 1 down to L₀ and L₁ and every power below
+// -1 up to L₋₁, or to L₀ and L₁ when p₀ = 0.
+func (ec *ellipticCubic) integrate(s float64, root int, l map[int]float64) float64 {
+
+	// Expand P about s. The factor of the root is exactly v - s.
+	var p [4]float64
+	p[0] = ec.c
+	for i := 0; i < 3; i++ {
+		q := ec.g[i] + ec.h[i]*s
+		if i == root {
+			q = 0
+		}
+		for k := i + 1; k > 0; k-- {
+			p[k] = p[k]*q + p[k-1]*ec.h[i]
+		}
+		p[0] *= q
+	}
+
+	plo, phi := ec.sqrtP()
+	bound := func(n int) float64 {
+		return math.Pow(ec.hi-s, float64(n))*phi - math.Pow(ec.lo-s, float64(n))*plo
+	}
+
+	lowest, highest := 0, 0
+	for i := range l {
+		if i < lowest {
+			lowest = i
+		}
+		if i > highest {
+			highest = i
+		}
+	}
+
+	var sum float64
+	last := -2
+	if root >= 0 {
+		last = -1
+	}
+	for m := lowest; m <= last; m++ {
+		if l[m] == 0 {
+			continue
+		}
+
+		// Solve the recurrence with n = m + 1 for Lₘ, whose coefficient
+		// is n·p₀, or at a root with n = m, where it is (n + 1/2)·p₁.
+		n, first := m+1, 0
+		if root >= 0 {
+			n, first = m, 1
+		}
+		f := l[m] / ((float64(n) + float64(first)/2) * p[first])
+		sum += f * bound(n)
+		for k := first + 1; k < 4; k++ {
+			l[n-1+k] -= f * (float64(n) + float64(k)/2) * p[k]
+		}
+	}
+	for m := highest; m >= 2; m-- {
+		if l[m] == 0 {
+			continue
+		}
+
+		// Solve the recurrence with n = m - 2 for Lₘ, whose coefficient
+		// is (n + 3/2)·p₃.
+		n := m - 2
+		f := l[m] / ((float64(n) + 1.5) * p[3])
+		sum += f * bound(n)
+		for k := 0; k < 3; k++ {
+			l[n-1+k] -= f * (float64(n) + float64(k)/2) * p[k]
+		}
+	}
+
+	if l[0] != 0 {
+		sum += l[0] * ec.j0
+	}
+	if l[1] != 0 {
+		sum += l[1] * (ec.j1 - s*ec.j0)
+	}
+	if l[-1] != 0 && root < 0 {
+		sum += l[-1] * ec.pole(s)
+	}
+
+	return sum
+}
+
+// polyRoots finds all roots of the polynomial Σ c[i]·zⁱ with the Aberth
+// iteration. The leading coefficient must not be zero.
+func polyRoots(c []float64) []complex128 {
+	n := len(c) - 1
+
+	// Start on a circle that holds all roots, at angles that avoid the
+	// symmetry of real coefficients.
+	var radius float64
+	for _, v := range c[:n] {
+		radius = math.Max(radius, math.Abs(v/c[n]))
+	}
+	radius++
+	z := make([]complex128, n)
+	for i := range z {
+		z[i] = cmplx.Rect(radius, 2*math.Pi*(float64(i)+0.25)/float64(n))
+	}
+
+	for iter := 0; iter < rootsMaxIter; iter++ {
+		done := true
+		for i := range z {
+			p, dp := polyEval(c, z[i])
+			if p == 0 {
+				continue
+			}
+			ratio := p / dp
+
+			var s complex128
+			for j := range z {
+				if j != i {
+					s += 1 / (z[i] - z[j])
+				}
+			}
+			w := ratio / (1 - ratio*s)
+			z[i] -= w
+
+			if cmplx.Abs(w) > 0x1p-52*cmplx.Abs(z[i]) {
+				done = false
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	return z
+}
+
+// polyEval evaluates Σ c[i]·zⁱ and its derivative with Horner's rule.
+func polyEval(c []float64, z complex128) (p, dp complex128) {
+	for i := len(c) - 1; i >= 0; i-- {
+		dp = dp*z + p
+		p = p*z + complex(c[i], 0)
+	}
+
+	return p, dp
+}
+
+// polishRoot refines a real root r of Σ c[i]·tⁱ with Newton steps in real
+// arithmetic, keeping a step only while it reduces the residual.
+func polishRoot(c []float64, r float64) float64 {
+	eval := func(t float64) (p, dp float64) {
+		for i := len(c) - 1; i >= 0; i-- {
+			dp = dp*t + p
+			p = p*t + c[i]
+		}
+		return p, dp
+	}
+
+	p, dp := eval(r)
+	for i := 0; i < 4 && p != 0 && dp != 0; i++ {
+		next := r - p/dp
+		q, dq := eval(next)
+		if math.Abs(q) >= math.Abs(p) {
+			break
+		}
+		r, p, dp = next, q, dq
+	}
+
+	return r
+}
@@ -0,0 +1,173 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+// poly evaluates Σ c[i]·tⁱ.
+func poly(c [5]float64, t float64) float64 {
+	var p float64
+	for i := len(c) - 1; i >= 0; i-- {
+		p = p*t + c[i]
+	}
+	return p
+}
+
+// endpointQuad integrates 1/√((t-x)(y-t)g(t)) over [x, y].
+// t = x + (y-x)(1 - cos θ)/2 takes the square root singularities at the
+// limits out of the integrand, which leaves a smooth integral over [0, π].
+func endpointQuad(g func(float64) float64, x, y float64) float64 {
+	f := func(theta float64) float64 {
+		return 1 / math.Sqrt(g(x+(y-x)*(1-math.Cos(theta))/2))
+	}
+	return simpson(f, 0, math.Pi, 1e-14)
+}
+
+func TestReduceQuarticIntegral(t *testing.T) {
+
+	// (t - 1)(t - 2)(t - 3)(t - 4) = t⁴ - 10t³ + 35t² - 50t + 24.
+	quartic := [5]float64{24, -50, 35, -10, 1}
+
+	// 4t³ - 7t - 3 with the roots 3/2, -1/2 and -1.
+	cubic := [5]float64{-3, -7, 0, 4, 0}
+
+	tt := []struct {
+		name   string
+		coeffs [5]float64
+		x, y   float64
+	}{
+		{"above", quartic, 4.5, 7},
+		{"below", quartic, -3, 0.5},
+		{"between", [5]float64{-24, 50, -35, 10, -1}, 1.2, 1.9},
+		{"scaled", [5]float64{72, -150, 105, -30, 3}, 5, 6},
+		{"cubic", cubic, 2, 10},
+		{"cubicBetween", cubic, -0.9, -0.6},
+	}
+
+	t.Log("Given the need to reduce ∫ R(t) dt/√p(t) to Carlson's integrals.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen integrating over [%v, %v].", testID, test.x, test.y)
+				{
+					f := func(s float64) float64 { return 1 / math.Sqrt(poly(test.coeffs, s)) }
+					want := simpson(f, test.x, test.y, 1e-15)
+					got, err := mathext.ReduceQuarticIntegral(test.coeffs, test.x, test.y)
+					if err != nil || relErr(got, want) > 1e-12 {
+						t.Fatalf("\t%s\tTest %d:\tShould match quadrature %v, got %v, %v.", failed, testID, want, got, err)
+					}
+					if back, _ := mathext.ReduceQuarticIntegral(test.coeffs, test.y, test.x); back != -got {
+						t.Fatalf("\t%s\tTest %d:\tShould change sign with the limits, got %v.", failed, testID, back)
+					}
+					t.Logf("\t%s\tTest %d:\tShould match quadrature %v.", succeed, testID, want)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		testID := len(tt)
+		t.Logf("\tTest %d:\tWhen the limits are roots.", testID)
+		{
+			neg := [5]float64{-24, 50, -35, 10, -1}
+			got, err := mathext.ReduceQuarticIntegral(neg, 1, 2)
+			g := func(t float64) float64 { return (3 - t) * (4 - t) }
+			if want := endpointQuad(g, 1, 2); err != nil || relErr(got, want) > 1e-12 {
+				t.Fatalf("\t%s\tTest %d:\tShould match quadrature %v, got %v, %v.", failed, testID, want, got, err)
+			}
+
+			// Between two neighbouring roots the integral is a complete
+			// integral, 2K(m)/√((r₃ - r₁)(r₄ - r₂)) with the cross ratio m.
+			if want := 2 * mathext.CompleteK(0.25) / math.Sqrt(4); relErr(got, want) > 1e-14 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 2K(1/4)/2 = %v, got %v.", failed, testID, want, got)
+			}
+			t.Logf("\t%s\tTest %d:\tShould handle the singular limits.", succeed, testID)
+		}
+
+		testID++
+		t.Logf("\tTest %d:\tWhen checking the Weierstrass cubic.", testID)
+		{
+
+			// ∫ dt/√(4t³ - g₂t - g₃) from ℘(v) to ℘(u) is v - u on the real
+			// half-period.
+			const g2, g3, u, v = 7, 3, 0.3, 0.6
+			x, y := real(mathext.WeierstrassP(v, g2, g3)), real(mathext.WeierstrassP(u, g2, g3))
+			got, err := mathext.ReduceQuarticIntegral([5]float64{-g3, -g2, 0, 4, 0}, x, y)
+			if err != nil || math.Abs(got-(v-u)) > 1e-14 {
+				t.Fatalf("\t%s\tTest %d:\tShould get %v, got %v, %v.", failed, testID, v-u, got, err)
+			}
+			t.Logf("\t%s\tTest %d:\tShould invert ℘.", succeed, testID)
+		}
+
+		testID++
+		t.Logf("\tTest %d:\tWhen R(t) has polynomial terms and poles.", testID)
+		{
+			neg := [5]float64{-24, 50, -35, 10, -1}
+			rs := []struct {
+				coeffs [5]float64
+				x, y   float64
+				terms  []mathext.QuarticTerm
+			}{
+				{quartic, 4.5, 7, []mathext.QuarticTerm{{1, 0, 3}, {-2, 0, 1}, {2, 1.5, 2}}},
+				{quartic, -3, 0.5, []mathext.QuarticTerm{{1, 8, -2}, {3, -5, -1}, {1, 1.5, -1}}},
+				{neg, 1.2, 1.9, []mathext.QuarticTerm{{1, 1, -1}, {1, 2, -2}, {-1, 4, -3}}},
+				{cubic, 2, 10, []mathext.QuarticTerm{{1, 0, 2}, {1, 1.5, -2}, {1, -5, -1}}},
+				{cubic, -0.9, -0.6, []mathext.QuarticTerm{{1, 0, 5}, {1, -1, -1}, {2, -0.5, -3}}},
+			}
+			for _, r := range rs {
+				f := func(s float64) float64 {
+					var sum float64
+					for _, term := range r.terms {
+						sum += term.Coeff * math.Pow(s-term.Pole, float64(term.Power))
+					}
+					return sum / math.Sqrt(poly(r.coeffs, s))
+				}
+				want := simpson(f, r.x, r.y, 1e-14*math.Abs(simpson(f, r.x, r.y, 1e-6)))
+				got, err := mathext.ReduceQuarticIntegral(r.coeffs, r.x, r.y, r.terms...)
+				if err != nil || relErr(got, want) > 1e-12 {
+					t.Fatalf("\t%s\tTest %d:\tShould match quadrature %v for %v, got %v, %v.", failed, testID, want, r.terms, got, err)
+				}
+			}
+
+			// ∫ t² dt/√p(t) between two roots.
+			got, err := mathext.ReduceQuarticIntegral(neg, 1, 2, mathext.QuarticTerm{Coeff: 1, Power: 2})
+			g := func(t float64) float64 { return (3 - t) * (4 - t) / (t * t * t * t) }
+			if want := endpointQuad(g, 1, 2); err != nil || relErr(got, want) > 1e-12 {
+				t.Fatalf("\t%s\tTest %d:\tShould match quadrature %v, got %v, %v.", failed, testID, want, got, err)
+			}
+			t.Logf("\t%s\tTest %d:\tShould reduce to RF, RD, RJ and RC.", succeed, testID)
+		}
+
+		testID++
+		t.Logf("\tTest %d:\tWhen the integral cannot be reduced.", testID)
+		{
+			bad := []struct {
+				coeffs [5]float64
+				x, y   float64
+			}{
+				{[5]float64{1, 0, 1, 0, 0}, 0, 1},
+				{[5]float64{1, 0, 0, 0, 1}, 0, 1},
+				{quartic, 0, 1.5},
+				{quartic, 1.2, 1.8},
+				{quartic, 2, math.Inf(1)},
+				{[5]float64{math.NaN(), 0, 0, 0, 1}, 0, 1},
+			}
+			for _, b := range bad {
+				if got, err := mathext.ReduceQuarticIntegral(b.coeffs, b.x, b.y); err == nil || !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould fail for %v on [%v, %v], got %v.", failed, testID, b.coeffs, b.x, b.y, got)
+				}
+			}
+			for _, term := range []mathext.QuarticTerm{{1, 5, -1}, {1, 4.5, -2}, {math.NaN(), 0, 1}} {
+				if got, err := mathext.ReduceQuarticIntegral(quartic, 4.5, 7, term); err == nil || !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould fail for the term %v, got %v.", failed, testID, term, got)
+				}
+			}
+			if got, err := mathext.ReduceQuarticIntegral(quartic, 5, 5); err != nil || got != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 0 over an empty interval, got %v, %v.", failed, testID, got, err)
+			}
+			t.Logf("\t%s\tTest %d:\tShould report an error.", succeed, testID)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package mathext_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestCompleteSeriesCoeffs(t *testing.T) {
+	kWant := []float64{1, 1.0 / 4, 9.0 / 64, 25.0 / 256, 1225.0 / 16384, 3969.0 / 65536}
+	eWant := []float64{1, -1.0 / 4, -3.0 / 64, -5.0 / 256, -175.0 / 16384, -441.0 / 65536}
+
+	t.Log("Given the need to access the Maclaurin coefficients of K and E.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the first six coefficients.")
+		{
+			k := mathext.CompleteKSeriesCoeffs(6)
+			e := mathext.CompleteESeriesCoeffs(6)
+			for i := range kWant {
+				if want := math.Pi / 2 * kWant[i]; k[i] != want {
+					t.Fatalf("\t%s\tTest 0:\tShould get K coefficient %d = %v, got %v.", failed, i, want, k[i])
+				}
+				if want := math.Pi / 2 * eWant[i]; e[i] != want {
+					t.Fatalf("\t%s\tTest 0:\tShould get E coefficient %d = %v, got %v.", failed, i, want, e[i])
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match the closed forms.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen summing the series at m = 0.01.")
+		{
+			const m = 0.01
+			var k, e float64
+			kc, ec := mathext.CompleteKSeriesCoeffs(12), mathext.CompleteESeriesCoeffs(12)
+			for i := len(kc) - 1; i >= 0; i-- {
+				k = k*m + kc[i]
+				e = e*m + ec[i]
+			}
+			if relErr(k, mathext.CompleteK(m)) > 4e-16 || relErr(e, mathext.CompleteE(m)) > 4e-16 {
+				t.Fatalf("\t%s\tTest 1:\tShould match CompleteK and CompleteE, got %v and %v.", failed, k, e)
+			}
+			t.Logf("\t%s\tTest 1:\tShould match CompleteK and CompleteE.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen asking for no coefficients.")
+		{
+			if got := mathext.CompleteKSeriesCoeffs(0); len(got) != 0 {
+				t.Fatalf("\t%s\tTest 2:\tShould get an empty slice, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 2:\tShould get an empty slice.", succeed)
+		}
+	}
+}
+
+func TestCompleteKAsymptoticCoeffs(t *testing.T) {
+	aWant := []float64{0, -1.0 / 4, -21.0 / 128, -185.0 / 1536}
+	bWant := []float64{1.0 / 2, 1.0 / 8, 9.0 / 128, 25.0 / 512}
+
+	t.Log("Given the need to access the logarithmic expansion of K around m = 1.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the first four pairs.")
+		{
+			a, b := mathext.CompleteKAsymptoticCoeffs(4)
+			for i := range aWant {
+				if math.Abs(a[i]-aWant[i]) > ulp(aWant[i]) || b[i] != bWant[i] {
+					t.Fatalf("\t%s\tTest 0:\tShould get pair %d = (%v, %v), got (%v, %v).", failed, i, aWant[i], bWant[i], a[i], b[i])
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match the closed forms.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen summing sixteen pairs for m in [0.9, 1).")
+		{
+			a, b := mathext.CompleteKAsymptoticCoeffs(16)
+			for _, m := range []float64{0.9, 0.95, 0.99, 0.999999, 1 - 0x1p-40} {
+				mc := 1 - m
+				l := math.Log(16 / mc)
+				var k float64
+				for i := len(a) - 1; i >= 0; i-- {
+					k = k*mc + a[i] + b[i]*l
+				}
+				if e := relErr(k, mathext.CompleteK(m)); e > 4e-16 {
+					t.Fatalf("\t%s\tTest 1:\tShould match CompleteK(%v), got %v : rel err %g.", failed, m, k, e)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould match CompleteK.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen asking for a negative number of pairs.")
+		{
+			if a, b := mathext.CompleteKAsymptoticCoeffs(-1); a != nil || b != nil {
+				t.Fatalf("\t%s\tTest 2:\tShould get nil, got %v and %v.", failed, a, b)
+			}
+			t.Logf("\t%s\tTest 2:\tShould get nil.", succeed)
+		}
+	}
+}
+
+func TestCompleteESeries(t *testing.T) {
+	tt := []struct {
+		name   string
+		m      float64
+		terms  int
+		maxErr float64
+	}{
+		{"half/4", 0.5, 4, 2e-3},
+		{"half/8", 0.5, 8, 3e-5},
+		{"half/16", 0.5, 16, 3e-8},
+		{"half/32", 0.5, 32, 1e-13},
+		{"half/48", 0.5, 48, 4e-16},
+		{"nearOne/8", 0.9, 8, 7e-3},
+		{"nearOne/32", 0.9, 32, 6e-5},
+		{"nearOne/64", 0.9, 64, 6e-7},
+		{"nearOne/100", 0.9, 100, 6e-9},
+		{"nearOne/200", 0.9, 200, 1e-13},
+	}
+
+	t.Log("Given the need to trade accuracy for size with a truncated series for E.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking m=%v with %d terms.", testID, test.m, test.terms)
+				{
+					e := relErr(mathext.CompleteESeries(test.m, test.terms), mathext.CompleteE(test.m))
+					if e > test.maxErr {
+						t.Fatalf("\t%s\tTest %d:\tShould have rel err below %g, got %g.", failed, testID, test.maxErr, e)
+					}
+
+					// One more term never makes the approximation worse.
+					if next := relErr(mathext.CompleteESeries(test.m, test.terms+1), mathext.CompleteE(test.m)); next > e {
+						t.Fatalf("\t%s\tTest %d:\tShould improve with another term, got %g after %g.", failed, testID, next, e)
+					}
+					t.Logf("\t%s\tTest %d:\tShould have rel err below %g : got %g.", succeed, testID, test.maxErr, e)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+
+		t.Logf("\tTest %d:\tWhen checking the edge cases.", len(tt))
+		{
+			if got := mathext.CompleteESeries(0.3, 0); got != 0 {
+				t.Fatalf("\t%s\tTest %d:\tShould get 0 for no terms, got %v.", failed, len(tt), got)
+			}
+			if got := mathext.CompleteESeries(0, 5); got != math.Pi/2 {
+				t.Fatalf("\t%s\tTest %d:\tShould get π/2 at m = 0, got %v.", failed, len(tt), got)
+			}
+			for _, m := range []float64{-0.1, 1.1, math.NaN()} {
+				if got := mathext.CompleteESeries(m, 5); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest %d:\tShould get NaN for m=%v, got %v.", failed, len(tt), m, got)
+				}
+			}
+			t.Logf("\t%s\tTest %d:\tShould handle no terms, m = 0 and m out of domain.", succeed, len(tt))
+		}
+	}
+}
+
+// seriesEBig sums the first terms of the Maclaurin series of E at m with
+// 200 bits of precision and rounds the result to a float64.
+func seriesEBig(m float64, terms int) float64 {
+	const prec = 200
+	newFloat := func() *big.Float { return new(big.Float).SetPrec(prec) }
+
+	pi, _ := newFloat().SetString("3.14159265358979323846264338327950288419716939937510582097494459")
+	sum, r, p := newFloat().SetInt64(1), newFloat().SetInt64(1), newFloat().SetInt64(1)
+	bm := newFloat().SetFloat64(m)
+	for k := 1; k < terms; k++ {
+		r.Mul(r, newFloat().Quo(newFloat().SetInt64(int64(2*k-1)), newFloat().SetInt64(int64(2*k))))
+		p.Mul(p, bm)
+		t := newFloat().Mul(r, r)
+		t.Mul(t, p)
+		t.Quo(t, newFloat().SetInt64(int64(1-2*k)))
+		sum.Add(sum, t)
+	}
+	sum.Mul(sum, pi)
+	sum.Quo(sum, newFloat().SetInt64(2))
+
+	f, _ := sum.Float64()
+	return f
+}
+
+func TestCompleteESeriesCompensated(t *testing.T) {
+	const terms = 12
+
+	t.Log("Given the need to sum the series for E to within 1 ulp.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing the compensated and plain sums near m = 1e-4.")
+		{
+			var plain, comp, plainMax, compMax float64
+			for i := 0; i < 1000; i++ {
+				m := 1e-4 * (1 + float64(i)/1000)
+				want := seriesEBig(m, terms)
+				p := math.Abs(mathext.CompleteESeries(m, terms)-want) / ulp(want)
+				c := math.Abs(mathext.CompleteESeriesCompensated(m, terms)-want) / ulp(want)
+				plain, comp = plain+p, comp+c
+				plainMax, compMax = math.Max(plainMax, p), math.Max(compMax, c)
+			}
+			if compMax > 1 {
+				t.Fatalf("\t%s\tTest 0:\tShould stay within 1 ulp, got %v ulp.", failed, compMax)
+			}
+			if comp >= plain {
+				t.Fatalf("\t%s\tTest 0:\tShould beat the plain sum, got %v ulp in total against %v.", failed, comp, plain)
+			}
+			t.Logf("\t%s\tTest 0:\tShould beat the plain sum : %v ulp in total, max %v, against %v, max %v.", succeed, comp, compMax, plain, plainMax)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the edge cases.")
+		{
+			if got := mathext.CompleteESeriesCompensated(0, terms); got != math.Pi/2 {
+				t.Fatalf("\t%s\tTest 1:\tShould get π/2 at m = 0, got %v.", failed, got)
+			}
+			if got := mathext.CompleteESeriesCompensated(0.5, 0); got != 0 {
+				t.Fatalf("\t%s\tTest 1:\tShould get 0 for no terms, got %v.", failed, got)
+			}
+			if got := mathext.CompleteESeriesCompensated(-1, terms); !math.IsNaN(got) {
+				t.Fatalf("\t%s\tTest 1:\tShould get NaN out of domain, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 1:\tShould handle m = 0, no terms and m out of domain.", succeed)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package mathext
+
+import "math"
+
+// Theta1 computes the Jacobi theta function
+//
+//	θ₁(z, q) = 2 Σ_{n≥0} (-1)ⁿ q^{(n+½)²} sin((2n+1)z)
+//
+// from its q-series. q must be in [0, 1). The series converges fastest for
+// small q and needs more terms as q approaches 1, so above q = e^-π the
+// four functions switch to Jacobi's imaginary transformation. With
+// t = -ln q/π, which is below 1 there, it turns them into sums of
+// Gaussians over the multiples of π,
+//
+//	θ₁(z, q) = t^{-1/2} Σₙ (-1)ⁿ exp(-(z - (n+½)π)²/(πt))
+//	θ₂(z, q) = t^{-1/2} Σₙ (-1)ⁿ exp(-(z - nπ)²/(πt))
+//	θ₃(z, q) = t^{-1/2} Σₙ exp(-(z - nπ)²/(πt))
+//	θ₄(z, q) = t^{-1/2} Σₙ exp(-(z - (n+½)π)²/(πt))
+//
+// with n over all integers, whose terms fall at least as fast as e^{-πn}
+// away from the one closest to z.
+func Theta1(z, q float64) float64 {
+	v, _ := theta(1, z, q)
+	return v
+}
+
+// Theta2 computes the Jacobi theta function
+//
+//	θ₂(z, q) = 2 Σ_{n≥0} q^{(n+½)²} cos((2n+1)z)
+//
+// from its q-series, or above q = e^-π from its transformation, see
+// Theta1. q must be in [0, 1).
+func Theta2(z, q float64) float64 {
+	v, _ := theta(2, z, q)
+	return v
+}
+
+// Theta3 computes the Jacobi theta function
+//
+//	θ₃(z, q) = 1 + 2 Σ_{n≥1} q^{n²} cos(2nz)
+//
+// from its q-series, or above q = e^-π from its transformation, see
+// Theta1. q must be in [0, 1).
+func Theta3(z, q float64) float64 {
+	v, _ := theta(3, z, q)
+	return v
+}
+
+// Theta4 computes the Jacobi theta function
+//
+//	θ₄(z, q) = 1 + 2 Σ_{n≥1} (-1)ⁿ q^{n²} cos(2nz)
+//
+// from its q-series, or above q = e^-π from its transformation, see
+// Theta1. q must be in [0, 1).
+func Theta4(z, q float64) float64 {
+	v, _ := theta(4, z, q)
+	return v
+}
+
+// Theta1Prime computes the derivative of θ₁(z, q) with respect to z by
+// differentiating the q-series term by term. At z = 0 it returns the
+// normalization constant
+//
+//	θ₁'(0, q) = 2q^{1/4} Π_{n≥1} (1 - q^{2n})³
+//
+// from the product, which converges faster than the series and has no
+// alternating terms. Above q = e^-π both are slow and the transformed sum
+// of Theta1 is differentiated instead. q must be in [0, 1).
+func Theta1Prime(z, q float64) float64 {
+	if z == 0 && q >= 0 && q <= math.Exp(-math.Pi) {
+		return theta1PrimeZero(q)
+	}
+
+	_, dv := theta(1, z, q)
+	return dv
+}
+
+// Theta2Prime computes the derivative of θ₂(z, q) with respect to z.
+// q must be in [0, 1).
+func Theta2Prime(z, q float64) float64 {
+	_, dv := theta(2, z, q)
+	return dv
+}
+
+// Theta3Prime computes the derivative of θ₃(z, q) with respect to z.
+// q must be in [0, 1).
+func Theta3Prime(z, q float64) float64 {
+	_, dv := theta(3, z, q)
+	return dv
+}
+
+// Theta4Prime computes the derivative of θ₄(z, q) with respect to z.
+// q must be in [0, 1).
+func Theta4Prime(z, q float64) float64 {
+	_, dv := theta(4, z, q)
+	return dv
+}
+
+// theta sums the q-series of θⱼ(z, q) and of its derivative in z. The
+// weights q^{(n+½)²} and q^{n²} are built by repeated multiplication and
+// the sum stops once a term no longer changes the sum of magnitudes.
+// Above q = e^-π it hands over to thetaImaginary.
+func theta(j int, z, q float64) (v, dv float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(z) || math.IsInf(z, 0) || math.IsNaN(q) || q < 0 || q >= 1 {
+		return math.NaN(), math.NaN()
+	}
+
+	if q > math.Exp(-math.Pi) {
+		return thetaImaginary(j, z, q)
+	}
+
+	alt := j == 1 || j == 4
+	q2 := q * q
+
+	// θ₁ and θ₂ run over the half-integers with weights starting at
+	// q^{1/4}, θ₃ and θ₄ over the integers from n = 1 with a constant 1
+	// in front.
+	var w, step, k, scale float64
+	if j <= 2 {
+		w, step, k = math.Sqrt(math.Sqrt(q)), q2, 1
+	} else {
+		w, step, k, scale = q, q*q2, 2, 1
+	}
+
+	// The alternating series start with a positive term at n = 0 and a
+	// negative one at n = 1.
+	sign := 1.0
+	if j == 4 {
+		sign = -1
+	}
+
+	var sum, dsum float64
+	for ; w != 0; sign = -sign {
+		s, c := math.Sincos(k * z)
+		a := w
+		if alt {
+			a *= sign
+		}
+
+		// θ₁ is the only sine series.
+		if j == 1 {
+			sum += a * s
+			dsum += a * k * c
+		} else {
+			sum += a * c
+			dsum -= a * k * s
+		}
+
+		scale += w * k
+		if w*k <= 0x1p-54*scale {
+			break
+		}
+
+		w *= step
+		step *= q2
+		k += 2
+	}
+
+	if j <= 2 {
+		return 2 * sum, 2 * dsum
+	}
+
+	return 1 + 2*sum, 2 * dsum
+}
+
+// thetaImaginary sums the Gaussians of θⱼ(z, q) and of its derivative in
+// z that Jacobi's imaginary transformation gives, see Theta1. The sum
+// starts at the centre c = n or n + ½ closest to z/π and walks out to
+// both sides until the terms no longer change the sum of magnitudes. The
+// distance z - cπ is formed with the two parts of π like
+// reduceHalfPeriods, so it stays exact for large z.
+func thetaImaginary(j int, z, q float64) (v, dv float64) {
+	t := -math.Log(q) / math.Pi
+
+	// θ₁ and θ₄ are centred on the half-integers, θ₁ and θ₂ alternate.
+	off := 0.0
+	if j == 1 || j == 4 {
+		off = 0.5
+	}
+	alt := j == 1 || j == 2
+
+	n := math.Round(z/math.Pi - off)
+	sign := 1.0
+	if alt && math.Mod(n, 2) != 0 {
+		sign = -1
+	}
+	x := math.FMA(-(n + off), math.Pi, z)
+	x = math.FMA(-(n + off), piLo, x)
+
+	var sum, dsum, scale float64
+	for i := 0.0; ; i++ {
+		g := 0.0
+		for _, d := range []float64{x - i*math.Pi, x + i*math.Pi} {
+			e := math.Exp(-d * d / (math.Pi * t))
+			sum += sign * e
+			dsum -= sign * 2 * d / (math.Pi * t) * e
+			scale += e
+			g = math.Max(g, e)
+			if i == 0 {
+				break
+			}
+		}
+		if i > 0 && g <= 0x1p-54*scale {
+			break
+		}
+
+		if alt {
+			sign = -sign
+		}
+	}
+
+	r := math.Sqrt(t)
+	return sum / r, dsum / r
+}
+
+// theta1PrimeZero computes θ₁'(0, q) = 2q^{1/4} Π_{n≥1} (1 - q^{2n})³.
+func theta1PrimeZero(q float64) float64 {
+	p := 1.0
+	q2 := q * q
+	for t := q2; t > 0x1p-54; t *= q2 {
+		f := 1 - t
+		p *= f * f * f
+	}
+
+	return 2 * math.Sqrt(math.Sqrt(q)) * p
+}
+
+// ThetaConstants computes the theta constants θ₂(0, q), θ₃(0, q) and
+// θ₄(0, q) in one pass,
+//
+//	θ₂ = 2q^{1/4} Σ_{n≥0} q^{n(n+1)}
+//	θ₃ = 1 + 2 Σ_{n≥1} q^{n²},  θ₄ = 1 + 2 Σ_{n≥1} (-1)ⁿ q^{n²}
+//
+// which give m = θ₂⁴/θ₃⁴, 1 - m = θ₄⁴/θ₃⁴ and K(m) = π/2·θ₃² for the
+// nome q of m. Above q = e^-π the series are slow and θ₄ cancels, so
+// Jacobi's imaginary transformation with q' = exp(π²/ln q) is used
+// instead,
+//
+//	θ₂(0, q) = s·θ₄(0, q'),  θ₃(0, q) = s·θ₃(0, q'),  θ₄(0, q) = s·θ₂(0, q')
+//
+// with s = √(π/-ln q), and all three keep their relative precision up to
+// q = 1. q must be in [0, 1).
+func ThetaConstants(q float64) (t2, t3, t4 float64) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(q) || q < 0 || q >= 1 {
+		nan := math.NaN()
+		return nan, nan, nan
+	}
+
+	if q <= math.Exp(-math.Pi) {
+		return thetaNulls(q)
+	}
+
+	lnq := math.Log(q)
+	s := math.Sqrt(-math.Pi / lnq)
+	t2, t3, t4 = thetaNulls(math.Exp(math.Pi * math.Pi / lnq))
+
+	return s * t4, s * t3, s * t2
+}
+
+// thetaNulls sums the series of the theta constants for q <= e^-π, where
+// the terms fall faster than q^{2n}.
+func thetaNulls(q float64) (t2, t3, t4 float64) {
+
+	// even and odd collect q^{n²} over even and odd n >= 1.
+	s2, even, odd := 1.0, 0.0, 0.0
+	w2, step2 := q*q, q*q*q*q
+	w, step := q, q*q*q
+	for n := 1; w2 != 0 || w != 0; n++ {
+		s2 += w2
+		if n%2 == 0 {
+			even += w
+		} else {
+			odd += w
+		}
+		if w2 <= 0x1p-54*s2 && w <= 0x1p-54 {
+			break
+		}
+
+		// q^{n(n+1)} and q^{n²} grow their exponents by 2n + 2 and 2n + 1.
+		w2 *= step2
+		step2 *= q * q
+		w *= step
+		step *= q * q
+	}
+
+	return 2 * math.Sqrt(math.Sqrt(q)) * s2, 1 + 2*(even+odd), 1 + 2*(even-odd)
+}
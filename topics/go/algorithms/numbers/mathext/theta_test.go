@@ -0,0 +1,219 @@
+package mathext_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestTheta1PrimeZero(t *testing.T) {
+	t.Log("Given the need for the normalization constant θ₁'(0, q).")
+	{
+		for testID, q := range []float64{0.001, 0.05, math.Exp(-math.Pi), 0.3, 0.6} {
+			t.Logf("\tTest %d:\tWhen checking q=%v.", testID, q)
+			{
+				got := mathext.Theta1Prime(0, q)
+
+				// Sum the differentiated series directly.
+				var want float64
+				w, step, sign := math.Sqrt(math.Sqrt(q)), q*q, 1.0
+				for k := 1.0; w > 1e-300; k += 2 {
+					want += sign * k * w
+					w *= step
+					step *= q * q
+					sign = -sign
+				}
+				want *= 2
+
+				if e := relErr(got, want); e > 1e-14 {
+					t.Fatalf("\t%s\tTest %d:\tShould get %v from the series, got %v : rel err %g.", failed, testID, want, got, e)
+				}
+				t.Logf("\t%s\tTest %d:\tShould match the differentiated series.", succeed, testID)
+
+				jacobi := mathext.Theta2(0, q) * mathext.Theta3(0, q) * mathext.Theta4(0, q)
+				if e := relErr(got, jacobi); e > 1e-14 {
+					t.Fatalf("\t%s\tTest %d:\tShould equal θ₂θ₃θ₄ = %v, got %v : rel err %g.", failed, testID, jacobi, got, e)
+				}
+				t.Logf("\t%s\tTest %d:\tShould equal θ₂(0)θ₃(0)θ₄(0).", succeed, testID)
+			}
+		}
+	}
+}
+
+func TestThetaPrime(t *testing.T) {
+	const h = 0x1p-20
+
+	fns := []struct {
+		name  string
+		theta func(z, q float64) float64
+		prime func(z, q float64) float64
+	}{
+		{"Theta1", mathext.Theta1, mathext.Theta1Prime},
+		{"Theta2", mathext.Theta2, mathext.Theta2Prime},
+		{"Theta3", mathext.Theta3, mathext.Theta3Prime},
+		{"Theta4", mathext.Theta4, mathext.Theta4Prime},
+	}
+
+	t.Log("Given the need to differentiate the theta functions in z.")
+	{
+		for testID, test := range fns {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen comparing %sPrime with a central difference.", testID, test.name)
+				{
+					for _, q := range []float64{0.01, 0.2, 0.5} {
+						for _, z := range []float64{-2, 0.3, 1, 2.5} {
+							want := (test.theta(z+h, q) - test.theta(z-h, q)) / (2 * h)
+							got := test.prime(z, q)
+							if d := math.Abs(got - want); d > 1e-8*math.Max(1, math.Abs(want)) {
+								t.Fatalf("\t%s\tTest %d:\tShould get about %v at z=%v q=%v, got %v.", failed, testID, want, z, q, got)
+							}
+						}
+					}
+					t.Logf("\t%s\tTest %d:\tShould match the central difference.", succeed, testID)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestTheta(t *testing.T) {
+	t.Log("Given the need to evaluate the theta functions.")
+	{
+		t.Logf("\tTest 0:\tWhen checking θ₃(0, e^(-π)) = π^(1/4)/Γ(3/4).")
+		{
+			got := mathext.Theta3(0, math.Exp(-math.Pi))
+			if e := relErr(got, 1.0864348112133080); e > 4e-16 {
+				t.Fatalf("\t%s\tTest 0:\tShould get 1.0864348112133080, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 0:\tShould get 1.0864348112133080.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking θ₃⁴ = θ₂⁴ + θ₄⁴ at z = 0.")
+		{
+			for _, q := range []float64{0.01, 0.1, 0.4} {
+				t2, t3, t4 := mathext.Theta2(0, q), mathext.Theta3(0, q), mathext.Theta4(0, q)
+				lhs, rhs := math.Pow(t3, 4), math.Pow(t2, 4)+math.Pow(t4, 4)
+				if e := relErr(lhs, rhs); e > 1e-15 {
+					t.Fatalf("\t%s\tTest 1:\tShould satisfy Jacobi's identity at q=%v, got %v and %v.", failed, q, lhs, rhs)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould satisfy Jacobi's identity.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking q outside of [0, 1).")
+		{
+			for _, q := range []float64{-0.1, 1, math.NaN()} {
+				if got := mathext.Theta3(0.5, q); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest 2:\tShould get NaN for q=%v, got %v.", failed, q, got)
+				}
+				if got := mathext.Theta1Prime(0, q); !math.IsNaN(got) {
+					t.Fatalf("\t%s\tTest 2:\tShould get NaN for θ₁'(0, %v), got %v.", failed, q, got)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get NaN.", succeed)
+		}
+	}
+}
+
+func TestThetaNearOne(t *testing.T) {
+	tt := []struct {
+		name        string
+		theta       func(z, q float64) float64
+		prime       func(z, q float64) float64
+		z, q, v, dv float64
+	}{
+		{"Theta1", mathext.Theta1, mathext.Theta1Prime, 0.3, 0.9, 1.203777141179721e-06, 2.9038499365709362e-05},
+		{"Theta1", mathext.Theta1, mathext.Theta1Prime, 1.2, 0.99, 2.02431937766432e-05, 0.001493701703980678},
+		{"Theta1", mathext.Theta1, mathext.Theta1Prime, 1.5707, 0.999999, 1756.0831501875095, 338315.5536914767},
+		{"Theta2", mathext.Theta2, mathext.Theta2Prime, 2.9, 0.99, -0.053129174208588335, -2.554266517494626},
+		{"Theta2", mathext.Theta2, mathext.Theta2Prime, -9.4, 0.999, -30.336516841348505, 1502.6022459827395},
+		{"Theta3", mathext.Theta3, mathext.Theta3Prime, 1.2, 0.9, 6.332531568969856e-06, -0.0001442483046017684},
+		{"Theta3", mathext.Theta3, mathext.Theta3Prime, 0.01, 0.999, 50.70591074401354, -1013.6110712206969},
+		{"Theta4", mathext.Theta4, mathext.Theta4Prime, 2.9, 0.9, 2.848297709192847e-07, -7.186682416951473e-06},
+		{"Theta4", mathext.Theta4, mathext.Theta4Prime, -5, 0.99, 0.004709653339962742, 0.26955282268889},
+	}
+
+	t.Log("Given the need to evaluate the theta functions as q approaches 1.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with high precision values.")
+		{
+			for _, test := range tt {
+				if v, dv := test.theta(test.z, test.q), test.prime(test.z, test.q); relErr(v, test.v) > 1e-13 || relErr(dv, test.dv) > 1e-13 {
+					t.Fatalf("\t%s\tTest 0:\tShould get %v and %v from %s at z=%v q=%v, got %v and %v.", failed, test.v, test.dv, test.name, test.z, test.q, v, dv)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match to the conditioning in q.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen crossing q = e^-π, where the transformation takes over.")
+		{
+			q := math.Exp(-math.Pi)
+			for _, z := range []float64{-2, 0.3, 1, 2.5} {
+				for _, fn := range []func(z, q float64) float64{mathext.Theta1, mathext.Theta2, mathext.Theta3, mathext.Theta4, mathext.Theta3Prime} {
+					if a, b := fn(z, q), fn(z, math.Nextafter(q, 1)); relErr(a, b) > 1e-15 {
+						t.Fatalf("\t%s\tTest 1:\tShould be continuous at z=%v, got %v and %v.", failed, z, a, b)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould be continuous.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking θ₁'(0, q) = θ₂θ₃θ₄ next to q = 1.")
+		{
+			for _, q := range []float64{0.9, 0.99, 0.9999} {
+				t2, t3, t4 := mathext.ThetaConstants(q)
+				if got := mathext.Theta1Prime(0, q); relErr(got, t2*t3*t4) > 1e-13 {
+					t.Fatalf("\t%s\tTest 2:\tShould get %v at q=%v, got %v.", failed, t2*t3*t4, q, got)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould match the theta constants.", succeed)
+		}
+	}
+}
+
+func TestThetaConstants(t *testing.T) {
+	t.Log("Given the need for the theta constants.")
+	{
+		t.Logf("\tTest 0:\tWhen comparing with the theta functions at z = 0.")
+		{
+			for _, q := range []float64{0, 1e-10, 0.01, 0.04, 0.05, 0.2, 0.5} {
+				t2, t3, t4 := mathext.ThetaConstants(q)
+				if relErr(t2, mathext.Theta2(0, q)) > 1e-14 || relErr(t3, mathext.Theta3(0, q)) > 1e-14 || relErr(t4, mathext.Theta4(0, q)) > 1e-13 {
+					t.Fatalf("\t%s\tTest 0:\tShould match Theta2, Theta3 and Theta4 at q=%v, got %v %v %v.", failed, q, t2, t3, t4)
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match the theta functions.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking θ₃⁴ = θ₂⁴ + θ₄⁴ and recovering m.")
+		{
+			for _, m := range []float64{1e-12, 1e-4, 0.1, 0.5, 0.9, 0.999999, 1 - 1e-12} {
+				q := mathext.Nome(m)
+				t2, t3, t4 := mathext.ThetaConstants(q)
+				a, b, c := t2*t2*t2*t2, t3*t3*t3*t3, t4*t4*t4*t4
+				if relErr(b, a+c) > 1e-14 {
+					t.Fatalf("\t%s\tTest 1:\tShould get θ₃⁴ = θ₂⁴ + θ₄⁴ at m=%v, got %v and %v.", failed, m, b, a+c)
+				}
+				if relErr(a/b, m) > 1e-13 || relErr(c/b, 1-m) > 1e-12 {
+					t.Fatalf("\t%s\tTest 1:\tShould recover m=%v and 1-m, got %v and %v.", failed, m, a/b, c/b)
+				}
+				if k := math.Pi / 2 * t3 * t3; relErr(k, mathext.CompleteK(m)) > 1e-13 {
+					t.Fatalf("\t%s\tTest 1:\tShould get K(m) = π/2·θ₃² at m=%v, got %v.", failed, m, k)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould satisfy the identities.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking arguments outside of the domain.")
+		{
+			for _, q := range []float64{-0.1, 1, math.NaN()} {
+				if t2, _, _ := mathext.ThetaConstants(q); !math.IsNaN(t2) {
+					t.Fatalf("\t%s\tTest 2:\tShould get NaN at q=%v, got %v.", failed, q, t2)
+				}
+			}
+			t.Logf("\t%s\tTest 2:\tShould get NaN.", succeed)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package mathext
+
+import (
+	"fmt"
+	"math"
+)
+
+// validateTol is the tolerance Validate allows for every identity, as
+// 1e-13·max(|want|, 1). A correct build meets the identities to a few ulp,
+// so this leaves a wide margin and still catches a wrong branch or
+// coefficient.
+const validateTol = 1e-13
+
+// Validate checks the elliptic routines against each other on a grid of
+// arguments and returns an error naming the first identity that does not
+// hold to within 1e-13·max(|want|, 1). It checks
+//
+//	Legendre's relation    EK' + E'K - KK' = π/2
+//	Carlson reduction      K = RF(0, 1-m, 1), E = RF - m/3·RD(0, 1-m, 1)
+//	arithmetic-geometric   K = π/(2·AGM(1, √(1-m)))
+//	complete limits        F(π/2|m) = K(m), E(π/2|m) = E(m)
+//	amplitude inverse      F(am(u|m)|m) = u
+//	Jacobi identities      sn² + cn² = 1, m·sn² + dn² = 1
+//	nome inverse           m(q(m)) = m
+//
+// It is meant as a smoke test of a build, for example on a new platform or
+// compiler, and returns nil when the package works as intended.
+func Validate() error {
+	for i := 1; i < 20; i++ {
+		m := float64(i) / 20
+		mc := 1 - m
+		k, e := completeK(m, mc), completeE(m, mc)
+
+		if r := LegendreRelationResidual(m); !validateClose(math.Pi/2+r, math.Pi/2) {
+			return fmt.Errorf("mathext: Legendre's relation does not hold at m=%v: residual %g", m, r)
+		}
+
+		f, d := rf(0, mc, 1), rd(0, mc, 1)
+		if !validateClose(k, f) || !validateClose(e, f-m/3*d) {
+			return fmt.Errorf("mathext: Carlson reduction of K and E does not hold at m=%v: K=%v E=%v, RF=%v RD=%v", m, k, e, f, d)
+		}
+
+		if a := math.Pi / (2 * AGM(1, math.Sqrt(mc))); !validateClose(k, a) {
+			return fmt.Errorf("mathext: arithmetic-geometric mean does not give K at m=%v: K=%v, AGM form %v", m, k, a)
+		}
+
+		if fk, ee := EllipticF(math.Pi/2, m), EllipticE(math.Pi/2, m); !validateClose(fk, k) || !validateClose(ee, e) {
+			return fmt.Errorf("mathext: incomplete integrals do not reach K and E at φ=π/2, m=%v: F=%v E=%v, K=%v E=%v", m, fk, ee, k, e)
+		}
+
+		for j := -4; j <= 8; j++ {
+			u := float64(j) * 0.45
+			if f := EllipticF(JacobiAmplitude(u, m), m); !validateClose(f, u) {
+				return fmt.Errorf("mathext: F(am(u|m)|m) = u does not hold at u=%v, m=%v: got %v", u, m, f)
+			}
+
+			sn, cn, dn := Jacobi(u, m)
+			if !validateClose(sn*sn+cn*cn, 1) || !validateClose(m*sn*sn+dn*dn, 1) {
+				return fmt.Errorf("mathext: Jacobi identities do not hold at u=%v, m=%v: sn=%v cn=%v dn=%v", u, m, sn, cn, dn)
+			}
+		}
+
+		if mq, _ := NomeInverseValDeriv(Nome(m)); !validateClose(mq, m) {
+			return fmt.Errorf("mathext: nome does not invert at m=%v: got %v", m, mq)
+		}
+	}
+
+	return nil
+}
+
+// validateClose reports whether got agrees with want to validateTol,
+// relative to want when |want| > 1 and absolute otherwise.
+func validateClose(got, want float64) bool {
+	return math.Abs(got-want) <= validateTol*math.Max(math.Abs(want), 1)
+}
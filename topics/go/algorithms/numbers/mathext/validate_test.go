@@ -0,0 +1,20 @@
+package mathext_test
+
+import (
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestValidate(t *testing.T) {
+	t.Log("Given the need to check the elliptic routines against each other.")
+	{
+		t.Logf("\tTest 0:\tWhen validating this build.")
+		{
+			if err := mathext.Validate(); err != nil {
+				t.Fatalf("\t%s\tTest 0:\tShould hold every identity : %v.", failed, err)
+			}
+			t.Logf("\t%s\tTest 0:\tShould hold every identity.", succeed)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package mathext
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// lemniscaticOmega is the real half-period of the lemniscatic lattice
+// g₂ = 1, g₃ = 0, which is K(1/2) = Γ(1/4)²/(4√π).
+const lemniscaticOmega = 1.85407467730137191843385034719526004621759882352176690558591
+
+// equianharmonicOmega is the real half-period of the equianharmonic
+// lattice g₂ = 0, g₃ = 1, which is Γ(1/3)³/(4π).
+const equianharmonicOmega = 1.529954037057193
+
+// The parameters of the equianharmonic case, sin²(π/12) = (2 - √3)/4 and
+// its complement cos²(π/12) = (2 + √3)/4.
+const (
+	equianharmonicM  = 0.06698729810778067
+	equianharmonicMc = 0.9330127018922193
+)
+
+// weierstrass holds the reduction of ℘(z; g₂, g₃) to a Jacobi function of
+// parameter m. For a rectangular lattice, where the cubic 4t³ - g₂t - g₃
+// has the real roots e₁ >= e₂ >= e₃,
+//
+//	℘(z) = e₃ + (e₁ - e₃)/sn²(√(e₁ - e₃)·z | m),  m = (e₂ - e₃)/(e₁ - e₃)
+//
+// and for a rhombic lattice, where e₂ is the only real root and
+// H² = (e₂ - e₁)(e₂ - e₃),
+//
+//	℘(z) = e₂ + H·(1 + cn(2√H·z | m))/(1 - cn(2√H·z | m)),  m = 1/2 - 3e₂/(4H)
+//
+// e is the root the value is measured from and h the scale e₁ - e₃ or H.
+type weierstrass struct {
+	rhombic bool
+	e, h    float64
+	m, mc   float64
+}
+
+// newWeierstrass reduces the invariants g₂ and g₃ to a Jacobi function.
+// The lemniscatic case g₃ = 0 and the equianharmonic case g₂ = 0 have
+// exact roots and parameters and skip the cubic. g₂ and g₃ must not both
+// be zero.
+func newWeierstrass(g2, g3 float64) weierstrass {
+	switch {
+	case g3 == 0 && g2 > 0:
+
+		// The roots are √g₂/2, 0 and -√g₂/2.
+		r := math.Sqrt(g2)
+		return weierstrass{e: -r / 2, h: r, m: 0.5, mc: 0.5}
+
+	case g3 == 0:
+
+		// The real root is 0 and the others are ±i√-g₂/2.
+		return weierstrass{rhombic: true, h: math.Sqrt(-g2) / 2, m: 0.5, mc: 0.5}
+
+	case g2 == 0:
+
+		// The real root is ∛(g₃/4) and H = √3·|e₂|.
+		e := math.Cbrt(g3 / 4)
+		w := weierstrass{rhombic: true, e: e, h: math.Sqrt(3) * math.Abs(e), m: equianharmonicM, mc: equianharmonicMc}
+		if g3 < 0 {
+			w.m, w.mc = w.mc, w.m
+		}
+		return w
+	}
+
+	delta := g2*g2*g2 - 27*g3*g3
+	switch {
+	case delta > 0:
+
+		// Three real roots √(g₂/3)·cos(θ + 2πk/3) with cos 3θ = √27·g₃/g₂^(3/2).
+		// The differences of the roots and m follow from the angle
+		// without subtracting the roots.
+		r := math.Sqrt(g2 / 3)
+		theta := math.Atan2(math.Sqrt(delta), math.Sqrt(27)*g3) / 3
+		s := math.Sin(theta + math.Pi/3)
+		return weierstrass{
+			e:  r * math.Cos(theta+2*math.Pi/3),
+			h:  math.Sqrt(3) * r * s,
+			m:  math.Sin(theta) / s,
+			mc: math.Sin(math.Pi/3-theta) / s,
+		}
+
+	case delta == 0:
+
+		// A double root e = -3g₃/(2g₂) and a simple root -2e. The Jacobi
+		// function degenerates to a hyperbolic or trigonometric one.
+		e := -3 * g3 / (2 * g2)
+		if e > 0 {
+			return weierstrass{e: -2 * e, h: 3 * e, m: 1, mc: 0}
+		}
+		return weierstrass{e: e, h: -3 * e, m: 0, mc: 1}
+	}
+
+	// One real root, found with the hyperbolic form of the cubic formula,
+	// which does not cancel.
+	var e float64
+	if g2 > 0 {
+		r := math.Sqrt(g2 / 3)
+		e = math.Copysign(r*math.Cosh(math.Acosh(math.Sqrt(27)*math.Abs(g3)/(g2*math.Sqrt(g2)))/3), g3)
+	} else {
+		r := math.Sqrt(-g2 / 3)
+		e = r * math.Sinh(math.Asinh(math.Sqrt(27)*g3/(-g2*math.Sqrt(-g2)))/3)
+	}
+
+	// |3e₂/(4H)| <= 1/2, which rounding can overshoot next to a double root.
+	h := math.Sqrt(3*e*e - g2/4)
+	t := math.Max(-0.5, math.Min(0.5, 3*e/(4*h)))
+	return weierstrass{rhombic: true, e: e, h: h, m: 0.5 - t, mc: 0.5 + t}
+}
+
+// p evaluates ℘(z) from the reduction.
+func (w weierstrass) p(z complex128) complex128 {
+	sh := complex(math.Sqrt(w.h), 0)
+	if !w.rhombic {
+		sn, _, _ := JacobiComplex(sh*z, w.m)
+		return complex(w.e, 0) + complex(w.h, 0)/(sn*sn)
+	}
+
+	// (1 + cn)/(1 - cn) = (1 + cn)²/sn² keeps its precision near the
+	// pole at z = 0 where cn approaches 1.
+	sn, cn, _ := JacobiComplex(2*sh*z, w.m)
+	r := (1 + cn) / (1 - cn)
+	if real(cn) > 0 {
+		r = (1 + cn) * (1 + cn) / (sn * sn)
+	}
+	return complex(w.e, 0) + complex(w.h, 0)*r
+}
+
+// dp evaluates ℘'(z) from the reduction by differentiating sn or cn.
+func (w weierstrass) dp(z complex128) complex128 {
+	sh := math.Sqrt(w.h)
+	if !w.rhombic {
+		sn, cn, dn := JacobiComplex(complex(sh, 0)*z, w.m)
+		return complex(-2*w.h*sh, 0) * cn * dn / (sn * sn * sn)
+	}
+
+	// d/du (1 + cn)/(1 - cn) = -2·sn·dn/(1 - cn)², and next to the pole
+	// 1 - cn is replaced by sn²/(1 + cn) as in p.
+	sn, cn, dn := JacobiComplex(complex(2*sh, 0)*z, w.m)
+	if real(cn) > 0 {
+		return complex(-4*w.h*sh, 0) * dn * (1 + cn) * (1 + cn) / (sn * sn * sn)
+	}
+	return complex(-4*w.h*sh, 0) * sn * dn / ((1 - cn) * (1 - cn))
+}
+
+// WeierstrassP computes the Weierstrass elliptic function ℘(z; g₂, g₃)
+// for complex z and real invariants, the doubly periodic solution of
+//
+//	℘'(z)² = 4℘(z)³ - g₂℘(z) - g₃
+//
+// with a double pole at z = 0. It is reduced to sn or cn of a real
+// parameter through the roots of the cubic. The lemniscatic case g₃ = 0
+// and the equianharmonic case g₂ = 0 use their exact roots. With
+// g₂ = g₃ = 0 the lattice degenerates and ℘(z) = 1/z².
+func WeierstrassP(z complex128, g2, g3 float64) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(z) || cmplx.IsInf(z) || math.IsNaN(g2) || math.IsNaN(g3) || math.IsInf(g2, 0) || math.IsInf(g3, 0) {
+		return cmplx.NaN()
+	}
+
+	if g2 == 0 && g3 == 0 {
+		return 1 / (z * z)
+	}
+
+	return newWeierstrass(g2, g3).p(z)
+}
+
+// WeierstrassPPrime computes the derivative ℘'(z; g₂, g₃) of
+// WeierstrassP for complex z and real invariants. With the reductions of
+// WeierstrassP it is
+//
+//	℘'(z) = -2(e₁ - e₃)^(3/2)·cn·dn/sn³   of √(e₁ - e₃)·z   rectangular
+//	℘'(z) = -4H^(3/2)·sn·dn/(1 - cn)²     of 2√H·z          rhombic
+//
+// and it is odd with a triple pole at z = 0, where ℘'(z) = -2/z³ + ...
+// Together with ℘ it satisfies ℘'² = 4℘³ - g₂℘ - g₃, and it vanishes at
+// the half-periods. With g₂ = g₃ = 0 it is -2/z³.
+func WeierstrassPPrime(z complex128, g2, g3 float64) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(z) || cmplx.IsInf(z) || math.IsNaN(g2) || math.IsNaN(g3) || math.IsInf(g2, 0) || math.IsInf(g3, 0) {
+		return cmplx.NaN()
+	}
+
+	if g2 == 0 && g3 == 0 {
+		return -2 / (z * z * z)
+	}
+
+	return newWeierstrass(g2, g3).dp(z)
+}
+
+// WeierstrassHalfPeriods computes half-periods ω₁ and ω₃ of the lattice of
+// ℘(z; g₂, g₃) for real invariants, so that 2ω₁ and 2ω₃ generate it. ω₁
+// is real and positive and ω₃ lies in the upper half plane. When the
+// discriminant g₂³ - 27g₃² is positive the lattice is rectangular and ω₃
+// is imaginary, when it is negative the lattice is rhombic and
+// ω₃ = (ω₁ + iω')/2 with iω' the imaginary half-period.
+//
+// The two symmetric lattices return the closed forms
+//
+//	lemniscatic     g₃ = 0, g₂ > 0   ω₁ = Γ(1/4)²/(4√π)·g₂^(-1/4),   ω₃ = iω₁
+//	equianharmonic  g₂ = 0, g₃ > 0   ω₁ = Γ(1/3)³/(4π)·g₃^(-1/6),    ω₃ = e^(iπ/3)·ω₁
+//
+// together with their rotations for g₂ < 0 and g₃ < 0, so g₂ = 1 and
+// g₃ = 1 give the constants correctly rounded. When the discriminant is
+// zero one of the periods is infinite.
+func WeierstrassHalfPeriods(g2, g3 float64) (omega1, omega3 complex128) {
+
+	// Reject arguments outside of the domain.
+	if math.IsNaN(g2) || math.IsNaN(g3) || math.IsInf(g2, 0) || math.IsInf(g3, 0) {
+		return cmplx.NaN(), cmplx.NaN()
+	}
+
+	inf := math.Inf(1)
+	switch {
+	case g2 == 0 && g3 == 0:
+		return complex(inf, 0), complex(0, inf)
+
+	case g3 == 0 && g2 > 0:
+		w := lemniscaticOmega / math.Sqrt(math.Sqrt(g2))
+		return complex(w, 0), complex(0, w)
+
+	case g3 == 0:
+		w := math.Sqrt2 * lemniscaticOmega / math.Sqrt(math.Sqrt(-g2))
+		return complex(w, 0), complex(w/2, w/2)
+
+	case g2 == 0 && g3 > 0:
+		w := equianharmonicOmega / math.Sqrt(math.Cbrt(g3))
+		return complex(w, 0), complex(w/2, math.Sqrt(3)/2*w)
+
+	case g2 == 0:
+		w := equianharmonicOmega / math.Sqrt(math.Cbrt(-g3))
+		return complex(math.Sqrt(3)*w, 0), complex(math.Sqrt(3)/2*w, w/2)
+	}
+
+	wp := newWeierstrass(g2, g3)
+	k, kc := completeK(wp.m, wp.mc), completeK(wp.mc, wp.m)
+	sh := math.Sqrt(wp.h)
+	if !wp.rhombic {
+		return complex(k/sh, 0), complex(0, kc/sh)
+	}
+
+	return complex(k/sh, 0), complex(k/(2*sh), kc/(2*sh))
+}
+
+// roots returns the roots e₁, e₂ and e₃ of 4t³ - g₂t - g₃ from the
+// reduction. For a rhombic lattice e₂ is the real root and e₁, e₃ are
+// -e₂/2 ± i·√(H² - 9e₂²/4), which follows from e₁ + e₂ + e₃ = 0.
+func (w weierstrass) roots() (e1, e2, e3 complex128) {
+	if !w.rhombic {
+		return complex(w.e+w.h, 0), complex(w.e+w.m*w.h, 0), complex(w.e, 0)
+	}
+
+	b := math.Sqrt(math.Max(0, (w.h-1.5*w.e)*(w.h+1.5*w.e)))
+	return complex(-w.e/2, b), complex(w.e, 0), complex(-w.e/2, -b)
+}
+
+// cubicRoots computes the roots of 4t³ - g₂t - g₃ for complex invariants
+// with Cardano's formula. Of the two square roots of the discriminant the
+// one that adds to g₃/8 in magnitude is taken, so the cube root does not
+// come from a cancelled difference, and every root is polished with a
+// Newton step on the cubic.
+func cubicRoots(g2, g3 complex128) (e1, e2, e3 complex128) {
+
+	// t³ + pt + q with p = -g₂/4 and q = -g₃/4.
+	p, q := -g2/4, -g3/4
+	s := cmplx.Sqrt(q*q/4 + p*p*p/27)
+	a := -q/2 + s
+	if b := -q/2 - s; cmplx.Abs(b) > cmplx.Abs(a) {
+		a = b
+	}
+	if a == 0 {
+		return 0, 0, 0
+	}
+
+	u := cmplx.Pow(a, 1.0/3)
+	omega := complex(-0.5, math.Sqrt(3)/2)
+	var e [3]complex128
+	for i := range e {
+		e[i] = u - p/(3*u)
+		if d := 12*e[i]*e[i] - g2; d != 0 {
+			e[i] -= (4*e[i]*e[i]*e[i] - g2*e[i] - g3) / d
+		}
+		u *= omega
+	}
+
+	return e[0], e[1], e[2]
+}
+
+// WeierstrassPInverse computes a z with ℘(z; g₂, g₃) = w, the elliptic
+// integral
+//
+//	z = ∫_w^∞ dt / √(4t³ - g₂t - g₃) = RF(w - e₁, w - e₂, w - e₃)
+//
+// with e₁, e₂ and e₃ the roots of the cubic (DLMF 19.25.35). z is unique up
+// to sign and the lattice of periods. The invariants may be complex, in
+// which case the roots come from Cardano's formula. Real invariants take
+// the roots of the reduction of WeierstrassP instead, and for a
+// rectangular lattice and real w >= e₁ z is real and in (0, ω₁]. A
+// difference on the negative real axis, the branch cut of RF, is taken
+// as the limit from the upper half plane. w = ∞ gives z = 0.
+func WeierstrassPInverse(w, g2, g3 complex128) complex128 {
+
+	// Reject arguments outside of the domain.
+	if cmplx.IsNaN(w) || cmplx.IsNaN(g2) || cmplx.IsNaN(g3) || cmplx.IsInf(g2) || cmplx.IsInf(g3) {
+		return cmplx.NaN()
+	}
+
+	if cmplx.IsInf(w) {
+		return 0
+	}
+
+	var e1, e2, e3 complex128
+	switch {
+	case imag(g2) != 0 || imag(g3) != 0:
+		e1, e2, e3 = cubicRoots(g2, g3)
+	case g2 != 0 || g3 != 0:
+		e1, e2, e3 = newWeierstrass(real(g2), real(g3)).roots()
+	}
+	d := [3]complex128{w - e1, w - e2, w - e3}
+
+	// The smallest positive float64 moves a difference on the negative
+	// real axis to the upper side of the cut without changing its value.
+	for i := range d {
+		if onCut(d[i]) {
+			d[i] = complex(real(d[i]), math.SmallestNonzeroFloat64)
+		}
+	}
+
+	return RFComplex(d[0], d[1], d[2])
+}
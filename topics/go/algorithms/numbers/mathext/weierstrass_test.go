@@ -0,0 +1,255 @@
+package mathext_test
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/ardanlabs/gotraining/topics/go/algorithms/numbers/mathext"
+)
+
+func TestWeierstrassHalfPeriodsSymmetric(t *testing.T) {
+	tt := []struct {
+		name   string
+		g2, g3 float64
+		omega1 complex128
+		omega3 complex128
+	}{
+		{"lemniscatic", 1, 0, 1.8540746773013719, 1.8540746773013719i},
+		{"lemniscatic scaled", 16, 0, 0.9270373386506859, 0.9270373386506859i},
+		{"pseudo-lemniscatic", -1, 0, 2.6220575542921196, 1.3110287771460598 + 1.3110287771460598i},
+		{"equianharmonic", 0, 1, 1.529954037057193, 0.7649770185285965 + 1.3249790627140874i},
+		{"equianharmonic scaled", 0, 64, 0.7649770185285965, 0.38248850926429825 + 0.6624895313570437i},
+		{"equianharmonic rotated", 0, -1, 2.6499581254281748, 1.3249790627140874 + 0.7649770185285965i},
+	}
+
+	t.Log("Given the need to get exact half-periods of the symmetric Weierstrass lattices.")
+	{
+		for testID, test := range tt {
+			tf := func(t *testing.T) {
+				t.Logf("\tTest %d:\tWhen checking g2=%v, g3=%v.", testID, test.g2, test.g3)
+				{
+					omega1, omega3 := mathext.WeierstrassHalfPeriods(test.g2, test.g3)
+					if omega1 != test.omega1 || omega3 != test.omega3 {
+						t.Fatalf("\t%s\tTest %d:\tShould get %v and %v, got %v and %v.", failed, testID, test.omega1, test.omega3, omega1, omega3)
+					}
+					t.Logf("\t%s\tTest %d:\tShould get %v and %v.", succeed, testID, test.omega1, test.omega3)
+				}
+			}
+			t.Run(test.name, tf)
+		}
+	}
+}
+
+func TestWeierstrassP(t *testing.T) {
+	invariants := [][2]float64{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {4, 1}, {4, -1}, {1, 1}, {-2, 1}, {-2, -3}}
+
+	t.Log("Given the need to evaluate the Weierstrass elliptic function.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the Laurent series at the pole.")
+		{
+			for _, g := range invariants {
+				g2, g3 := g[0], g[1]
+				for _, z := range []complex128{0.01, 0.01i, 0.003 - 0.004i} {
+					z2 := z * z
+					want := 1/z2 + complex(g2/20, 0)*z2 + complex(g3/28, 0)*z2*z2
+					if got := mathext.WeierstrassP(z, g2, g3); cmplx.Abs(got-want) > 1e-14*cmplx.Abs(want) {
+						t.Fatalf("\t%s\tTest 0:\tShould get %v at z=%v for g2=%v, g3=%v, got %v.", failed, want, z, g2, g3, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match 1/z² + g2·z²/20 + g3·z⁴/28.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the periods and the roots of the cubic.")
+		{
+			for _, g := range invariants {
+				g2, g3 := g[0], g[1]
+				omega1, omega3 := mathext.WeierstrassHalfPeriods(g2, g3)
+
+				for _, z := range []complex128{0.3 + 0.1i, 0.7 - 0.4i, 1.1 + 0.9i, -0.2 + 0.5i} {
+					p := mathext.WeierstrassP(z, g2, g3)
+					for _, w := range []complex128{2 * omega1, 2 * omega3} {
+						if got := mathext.WeierstrassP(z+w, g2, g3); cmplx.Abs(got-p) > 1e-13*cmplx.Abs(p) {
+							t.Fatalf("\t%s\tTest 1:\tShould repeat after %v for g2=%v, g3=%v: got %v, want %v.", failed, w, g2, g3, got, p)
+						}
+					}
+				}
+
+				var sum complex128
+				for _, w := range []complex128{omega1, omega3, omega1 + omega3} {
+					e := mathext.WeierstrassP(w, g2, g3)
+					if r := 4*e*e*e - complex(g2, 0)*e - complex(g3, 0); cmplx.Abs(r) > 1e-14 {
+						t.Fatalf("\t%s\tTest 1:\tShould get a root of the cubic at %v for g2=%v, g3=%v: residual %v.", failed, w, g2, g3, r)
+					}
+					sum += e
+				}
+				if cmplx.Abs(sum) > 1e-14 {
+					t.Fatalf("\t%s\tTest 1:\tShould get roots summing to 0 for g2=%v, g3=%v: got %v.", failed, g2, g3, sum)
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould repeat after 2ω₁ and 2ω₃ and take the roots at the half-periods.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen the lattice degenerates.")
+		{
+			if got := mathext.WeierstrassP(0.5+0.5i, 0, 0); got != 1/((0.5+0.5i)*(0.5+0.5i)) {
+				t.Fatalf("\t%s\tTest 2:\tShould get 1/z² for g2 = g3 = 0, got %v.", failed, got)
+			}
+			if got, want := mathext.WeierstrassP(0.5, 3, 1), complex(-0.5+1.5/math.Pow(math.Sin(math.Sqrt(1.5)*0.5), 2), 0); cmplx.Abs(got-want) > 1e-15*cmplx.Abs(want) {
+				t.Fatalf("\t%s\tTest 2:\tShould get the trigonometric limit %v, got %v.", failed, want, got)
+			}
+			if got := mathext.WeierstrassP(cmplx.NaN(), 1, 0); !cmplx.IsNaN(got) {
+				t.Fatalf("\t%s\tTest 2:\tShould get NaN for a NaN argument, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 2:\tShould handle the degenerate lattices.", succeed)
+		}
+	}
+}
+
+func TestWeierstrassPPrime(t *testing.T) {
+	invariants := [][2]float64{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {4, 1}, {4, -1}, {1, 1}, {-2, 1}, {-2, -3}}
+
+	t.Log("Given the need for the derivative of the Weierstrass elliptic function.")
+	{
+		t.Logf("\tTest 0:\tWhen checking the Laurent series at the pole.")
+		{
+			for _, g := range invariants {
+				g2, g3 := g[0], g[1]
+				for _, z := range []complex128{0.01, 0.01i, 0.003 - 0.004i} {
+					z2 := z * z
+					want := -2/(z2*z) + complex(g2/10, 0)*z + complex(g3/7, 0)*z2*z
+					if got := mathext.WeierstrassPPrime(z, g2, g3); cmplx.Abs(got-want) > 1e-14*cmplx.Abs(want) {
+						t.Fatalf("\t%s\tTest 0:\tShould get %v at z=%v for g2=%v, g3=%v, got %v.", failed, want, z, g2, g3, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould match -2/z³ + g2·z/10 + g3·z³/7.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen checking the differential equation and a central difference.")
+		{
+			const h = 0x1p-20
+			for _, g := range invariants {
+				g2, g3 := g[0], g[1]
+				for _, z := range []complex128{0.3 + 0.1i, 0.7 - 0.4i, 1.1 + 0.9i, -0.2 + 0.5i, 0.9, -1.3} {
+					p := mathext.WeierstrassP(z, g2, g3)
+					dp := mathext.WeierstrassPPrime(z, g2, g3)
+					rhs := 4*p*p*p - complex(g2, 0)*p - complex(g3, 0)
+					if cmplx.Abs(dp*dp-rhs) > 1e-12*math.Max(1, cmplx.Abs(rhs)) {
+						t.Fatalf("\t%s\tTest 1:\tShould get ℘'² = %v at z=%v for g2=%v, g3=%v, got %v.", failed, rhs, z, g2, g3, dp*dp)
+					}
+					want := (mathext.WeierstrassP(z+h, g2, g3) - mathext.WeierstrassP(z-h, g2, g3)) / (2 * h)
+					if cmplx.Abs(dp-want) > 1e-8*math.Max(1, cmplx.Abs(want)) {
+						t.Fatalf("\t%s\tTest 1:\tShould get about %v at z=%v for g2=%v, g3=%v, got %v.", failed, want, z, g2, g3, dp)
+					}
+					if got := mathext.WeierstrassPPrime(-z, g2, g3); cmplx.Abs(got+dp) > 1e-14*cmplx.Abs(dp) {
+						t.Fatalf("\t%s\tTest 1:\tShould be odd at z=%v for g2=%v, g3=%v, got %v and %v.", failed, z, g2, g3, dp, got)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould satisfy ℘'² = 4℘³ - g2℘ - g3 and be odd.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking the half-periods and the degenerate lattice.")
+		{
+			for _, g := range invariants {
+				g2, g3 := g[0], g[1]
+				omega1, omega3 := mathext.WeierstrassHalfPeriods(g2, g3)
+				for _, w := range []complex128{omega1, omega3, omega1 + omega3} {
+					if got := mathext.WeierstrassPPrime(w, g2, g3); cmplx.Abs(got) > 1e-7 {
+						t.Fatalf("\t%s\tTest 2:\tShould vanish at %v for g2=%v, g3=%v, got %v.", failed, w, g2, g3, got)
+					}
+				}
+			}
+			if got := mathext.WeierstrassPPrime(0.5+0.5i, 0, 0); got != -2/((0.5+0.5i)*(0.5+0.5i)*(0.5+0.5i)) {
+				t.Fatalf("\t%s\tTest 2:\tShould get -2/z³ for g2 = g3 = 0, got %v.", failed, got)
+			}
+			if got := mathext.WeierstrassPPrime(cmplx.NaN(), 1, 0); !cmplx.IsNaN(got) {
+				t.Fatalf("\t%s\tTest 2:\tShould get NaN for a NaN argument, got %v.", failed, got)
+			}
+			t.Logf("\t%s\tTest 2:\tShould vanish at the half-periods and handle g2 = g3 = 0.", succeed)
+		}
+	}
+}
+
+func TestWeierstrassPInverse(t *testing.T) {
+	invariants := [][2]float64{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {4, 1}, {4, -1}, {1, 1}, {-2, 1}, {-2, -3}, {0, 0}}
+
+	t.Log("Given the need to invert the Weierstrass elliptic function.")
+	{
+		t.Logf("\tTest 0:\tWhen round-tripping through WeierstrassP.")
+		{
+			for _, g := range invariants {
+				g2, g3 := g[0], g[1]
+				for _, w := range []complex128{3, 0.7 + 0.2i, -1.5 + 2i, 0.25i, -4 - 0.5i, 100, -0.3, 0.1} {
+					z := mathext.WeierstrassPInverse(w, complex(g2, 0), complex(g3, 0))
+					if got := mathext.WeierstrassP(z, g2, g3); cmplx.Abs(got-w) > 1e-13*math.Max(1, cmplx.Abs(w)) {
+						t.Fatalf("\t%s\tTest 0:\tShould get ℘(z) = %v back for g2=%v, g3=%v, got %v at z=%v.", failed, w, g2, g3, got, z)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 0:\tShould get w back.", succeed)
+		}
+
+		t.Logf("\tTest 1:\tWhen inverting real values above the largest root.")
+		{
+			for _, g := range [][2]float64{{1, 0}, {4, 1}, {4, -1}} {
+				g2, g3 := g[0], g[1]
+				omega1, _ := mathext.WeierstrassHalfPeriods(g2, g3)
+				e1 := mathext.WeierstrassP(omega1, g2, g3)
+				if z := mathext.WeierstrassPInverse(e1, complex(g2, 0), complex(g3, 0)); imag(z) != 0 || math.Abs(real(z)-real(omega1)) > 1e-14*real(omega1) {
+					t.Fatalf("\t%s\tTest 1:\tShould get ω₁ = %v at e₁ for g2=%v, g3=%v, got %v.", failed, omega1, g2, g3, z)
+				}
+				for _, w := range []float64{1.01, 2, 10, 1e6} {
+					z := mathext.WeierstrassPInverse(complex(w*real(e1), 0), complex(g2, 0), complex(g3, 0))
+					if imag(z) != 0 || real(z) <= 0 || real(z) >= real(omega1) {
+						t.Fatalf("\t%s\tTest 1:\tShould get a real z in (0, ω₁) for g2=%v, g3=%v, got %v.", failed, g2, g3, z)
+					}
+				}
+			}
+			t.Logf("\t%s\tTest 1:\tShould get real z with z(e₁) = ω₁.", succeed)
+		}
+
+		t.Logf("\tTest 2:\tWhen checking the edges of the domain.")
+		{
+			if z := mathext.WeierstrassPInverse(cmplx.Inf(), 1, 0); z != 0 {
+				t.Fatalf("\t%s\tTest 2:\tShould get 0 for w = ∞, got %v.", failed, z)
+			}
+			if z := mathext.WeierstrassPInverse(4, 0, 0); z != 0.5 {
+				t.Fatalf("\t%s\tTest 2:\tShould get 1/√w for g2 = g3 = 0, got %v.", failed, z)
+			}
+			if z := mathext.WeierstrassPInverse(cmplx.NaN(), 1, 0); !cmplx.IsNaN(z) {
+				t.Fatalf("\t%s\tTest 2:\tShould get NaN, got %v.", failed, z)
+			}
+			t.Logf("\t%s\tTest 2:\tShould handle the edges.", succeed)
+		}
+
+		t.Logf("\tTest 3:\tWhen the invariants are complex.")
+		{
+			const h = 0x1p-12
+			for _, g := range [][2]complex128{{1 + 1i, 0.5i}, {-2 + 0.3i, 1 - 2i}, {0.5i, 0}, {0, 3 + 1i}, {1, 1e-3i}} {
+				g2, g3 := g[0], g[1]
+				for _, w := range []complex128{3 + 1i, -1.5 + 2i, 0.25i, -4 - 0.5i} {
+
+					// dz/dw = -1/√(4w³ - g₂w - g₃), so its square is fixed.
+					z := func(d complex128) complex128 { return mathext.WeierstrassPInverse(w+d*h, g2, g3) }
+					dz := (8*(z(1)-z(-1)) - (z(2) - z(-2))) / (12 * h)
+					want := 1 / (4*w*w*w - g2*w - g3)
+					if cmplx.Abs(dz*dz-want) > 1e-8*cmplx.Abs(want) {
+						t.Fatalf("\t%s\tTest 3:\tShould get (dz/dw)² = %v at w=%v for g2=%v, g3=%v, got %v.", failed, want, w, g2, g3, dz*dz)
+					}
+				}
+
+				// ℘(z) = 1/z² + g₂z²/20 + g₃z⁴/28 inverts to
+				// z = w^(-1/2)·(1 + g₂/(40w²) + g₃/(56w³) + ...) for large w.
+				w := complex128(1e4 + 3e3i)
+				want := (1 + g2/(40*w*w) + g3/(56*w*w*w)) / cmplx.Sqrt(w)
+				if got := mathext.WeierstrassPInverse(w, g2, g3); cmplx.Abs(got-want) > 1e-15*cmplx.Abs(want) {
+					t.Fatalf("\t%s\tTest 3:\tShould get %v at w=%v for g2=%v, g3=%v, got %v.", failed, want, w, g2, g3, got)
+				}
+			}
+			t.Logf("\t%s\tTest 3:\tShould satisfy dz/dw = -1/√(4w³ - g2·w - g3) and the Laurent series.", succeed)
+		}
+	}
+}